@@ -1,41 +1,87 @@
 package struct_validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
 	ErrValidationFormat = errors.New("invalid validation format, expected [operator[:operand]]")
 	ErrType             = errors.New("invalid type, expected struct")
+	ErrNilPointer       = errors.New("invalid value, nil pointer to struct")
 	ErrUnsupCondition   = errors.New("unsupported condition")
 	ErrUnsupType        = errors.New("unsupported type")
+	ErrInvalidOperand   = errors.New("invalid operand")
+	ErrRequired         = errors.New("validation error, value is required")
+	ErrSeparator        = errors.New("conflicting separators")
 )
 
 const (
 	splitSymbol   = ":"
 	inSplitSymbol = ","
 	andSymbol     = "|"
+	orSymbol      = "||"
 	validationTag = "validate"
+	skipTag       = "-"
 )
 
+// ValidationError is a failed condition. Field is Path rendered as a string,
+// e.g. "Phones[2]".
 type ValidationError struct {
 	Field string
+	Path  Path
 	Err   error
 }
 
+// PathElem is a step of a Path: a struct field, a map entry when Key is set,
+// or a slice element otherwise.
+type PathElem struct {
+	Name  string
+	Index int
+	Key   interface{}
+}
+
+type Path []PathElem
+
+func (p Path) String() string {
+	var path strings.Builder
+	for i, e := range p {
+		if e.Key != nil {
+			path.WriteString(fmt.Sprintf("[%v]", e.Key))
+			continue
+		}
+		if e.Name == "" {
+			path.WriteString("[" + strconv.Itoa(e.Index) + "]")
+			continue
+		}
+		if i > 0 {
+			path.WriteByte('.')
+		}
+		path.WriteString(e.Name)
+	}
+	return path.String()
+}
+
+// newValidationError copies path, as the walkers reuse its backing array.
+func newValidationError(path Path, err error) ValidationError {
+	p := make(Path, len(path))
+	copy(p, path)
+	return ValidationError{Field: p.String(), Path: p, Err: err}
+}
+
 type Condition struct {
 	operator string
 	operand  string
-}
+	params   []string // operand split into list values
+	negated  bool     // the condition must not be satisfied
 
-type Field struct {
-	name  string
-	value reflect.Value
-	tag   string
-	kind  reflect.Kind
+	anyOf [][]Condition // alternatives, at least one must be satisfied
 }
 
 type ValidationErrors []ValidationError
@@ -43,103 +89,109 @@ type ValidationErrors []ValidationError
 func (v ValidationErrors) Error() string {
 	var errors strings.Builder
 	for _, err := range v {
+		if err.Field == "" {
+			// A failure of the validated struct itself, e.g. found by its hook.
+			errors.WriteString(err.Err.Error())
+			continue
+		}
 		errors.WriteString(fmt.Sprintf("%v: %v", err.Field, err.Err.Error()))
 	}
 	return errors.String()
 }
 
-func parseConditions(tag string) ([]Condition, error) {
-	cond := []Condition{}
-	conditions := strings.Split(tag, andSymbol)
+// Validator validates structs by their validate tags. Validation plans are
+// compiled once per struct type and cached, so a Validator should be reused.
+// It's safe for concurrent use.
+type Validator struct {
+	tagName   string
+	syntax    syntax
+	failFast  bool
+	byteLen   bool
+	now       func() time.Time
+	fieldName func(reflect.StructField) string
+	plans     sync.Map // reflect.Type -> *structPlan
+
+	mu    sync.RWMutex
+	funcs map[string]ValidationFunc
+}
 
-	for _, c := range conditions {
-		splitedCond := strings.Split(c, splitSymbol)
-		if len(splitedCond) > 2 {
-			return nil, ErrValidationFormat
-		}
-		if len(splitedCond) == 2 {
-			cond = append(cond, Condition{operator: splitedCond[0], operand: splitedCond[1]})
-			continue
-		}
-		cond = append(cond, Condition{operator: splitedCond[0], operand: ""})
+var defaultValidator = New()
+
+// New returns a Validator configured by opts. It panics with an error wrapping
+// ErrSeparator if the separators set by the options conflict, e.g. when "|"
+// is made both the "and" and the "or" separator.
+func New(opts ...Option) *Validator {
+	v := &Validator{tagName: validationTag, syntax: defaultSyntax, now: time.Now}
+	for _, opt := range opts {
+		opt(v)
 	}
-	return cond, nil
+	if err := v.syntax.check(); err != nil {
+		panic(err)
+	}
+	return v
 }
 
-func parseFieldByTag(strct interface{}, expectedTag string) ([]Field, error) {
-	st := reflect.TypeOf(strct)
-	fields := []Field{}
-	if st.Kind() != reflect.Struct {
-		return nil, ErrType
-	}
+// Validate validates v with the default Validator.
+func Validate(v interface{}) error {
+	return defaultValidator.Validate(v)
+}
 
-	for i := 0; i < st.NumField(); i++ {
-		if !reflect.ValueOf(strct).Field(i).CanInterface() {
-			continue //  Unexported field
-		}
-		tag, ok := st.Field(i).Tag.Lookup(expectedTag)
-		if ok {
-			fields = append(fields, Field{
-				name:  st.Field(i).Name,
-				value: reflect.ValueOf(strct).Field(i),
-				tag:   tag,
-				kind:  st.Field(i).Type.Kind(),
-			})
-		}
-	}
-	return fields, nil
+// ValidateContext validates v with the default Validator, passing ctx to
+// custom validation funcs.
+func ValidateContext(ctx context.Context, v interface{}) error {
+	return defaultValidator.ValidateContext(ctx, v)
 }
 
-func validateField(field Field) error {
-	switch field.kind { //nolint:exhaustive
-	case reflect.Struct:
-		return validateStructField(field.tag, field.value)
-	case reflect.String:
-		return validateStringField(field.tag, []string{field.value.String()}, field.name)
-	case reflect.Int:
-		return validateIntField(field.tag, []int{int(field.value.Int())}, field.name)
-	case reflect.Slice:
-		switch field.value.Index(0).Kind() { //nolint:exhaustive
-		case reflect.String:
-			v := make([]string, field.value.Len())
-			for i := 0; i < len(v); i++ {
-				v[i] = field.value.Index(i).String()
-			}
-			return validateStringField(field.tag, v, field.name)
-		case reflect.Int:
-			v := make([]int, field.value.Len())
-			for i := 0; i < len(v); i++ {
-				v[i] = int(field.value.Index(i).Int())
-			}
-			return validateIntField(field.tag, v, field.name)
-		default:
-		}
-	default:
-	}
-	return ErrUnsupType
+func (v *Validator) Validate(s interface{}) error {
+	return v.ValidateContext(context.Background(), s)
 }
 
-func Validate(v interface{}) error {
-	var verr ValidationErrors
-	ve := ValidationErrors{}
+func (v *Validator) ValidateContext(ctx context.Context, s interface{}) error {
+	rv := reflect.ValueOf(s)
+	if !rv.IsValid() {
+		return ErrType
+	}
+	rv, ok := indirect(rv)
+	if !ok {
+		return ErrNilPointer
+	}
 
-	fields, err := parseFieldByTag(v, validationTag)
+	plan, err := v.planOf(rv.Type())
 	if err != nil {
 		return err
 	}
+	w := walker{v: v, ctx: ctx}
+	err = w.validateStruct(rv, plan, make(Path, 0, 8))
+	if err == nil && plan.hook != nil {
+		err = w.validateHook(rv, plan.hook, nil)
+	}
+	if err != nil && err != errStop { //nolint:errorlint
+		return err
+	}
+	if len(w.ve) != 0 {
+		return w.ve
+	}
+	return nil
+}
 
-	for _, f := range fields {
-		err = validateField(f)
-		if err != nil {
-			if !errors.As(err, &verr) {
-				return err
-			}
-			ve = append(ve, verr...)
+// nameOf is the name sf is reported by in error paths.
+func (v *Validator) nameOf(sf reflect.StructField) string {
+	if v.fieldName != nil {
+		if name := v.fieldName(sf); name != "" {
+			return name
 		}
 	}
+	return sf.Name
+}
 
-	if len(ve) != 0 {
-		return ve
+// indirect follows pointers and interfaces until it reaches a concrete
+// non-pointer value. It reports false if a nil one is met on the way.
+func indirect(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
 	}
-	return nil
+	return v, true
 }
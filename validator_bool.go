@@ -0,0 +1,28 @@
+package struct_validator
+
+import (
+	"errors"
+	"strconv"
+)
+
+var ErrValidationBoolEq = errors.New("validation error, bool isn't as expected")
+
+func boolRule(c Condition) (rule, error) {
+	switch c.operator {
+	case "eq":
+		b, err := strconv.ParseBool(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateBoolEq(fl.Value.Bool(), b) }}, nil
+	default:
+		return rule{}, ErrUnsupCondition
+	}
+}
+
+func validateBoolEq(field bool, exp bool) error {
+	if field != exp {
+		return ErrValidationBoolEq
+	}
+	return nil
+}
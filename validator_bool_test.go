@@ -0,0 +1,82 @@
+package struct_validator
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBoolField(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   string
+		value interface{}
+		err   error
+	}{
+		{name: "eq true", tag: "eq:true", value: true, err: nil},
+		{name: "eq true, false", tag: "eq:true", value: false, err: ErrValidationBoolEq},
+		{name: "eq false", tag: "eq:false", value: false, err: nil},
+		{name: "eq false, true", tag: "eq:0", value: true, err: ErrValidationBoolEq},
+		{name: "negated", tag: "!eq:true", value: true, err: ErrValidationNegated},
+		{name: "invalid operand", tag: "eq:yes", value: true, err: strconv.ErrSyntax},
+		{name: "unsupported condition", tag: "min:1", value: true, err: ErrUnsupCondition},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkRule(tc.tag, tc.value)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateBoolStruct(t *testing.T) {
+	type Signup struct {
+		AcceptedTerms bool   `validate:"eq:true"`
+		Newsletter    bool   `validate:"required_with:Email"`
+		Email         string `validate:"required_if:Newsletter,true"`
+		Admin         *bool  `validate:"omitempty|eqfield:Newsletter"`
+		Flags         []bool `validate:"minlen:1|dive|required"`
+	}
+	yes, no := true, false
+
+	tests := []struct {
+		name        string
+		in          Signup
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			in:          Signup{AcceptedTerms: true, Newsletter: true, Email: "bob@example.com", Admin: &yes, Flags: []bool{true}},
+			expectedErr: nil,
+		},
+		{
+			name: "required when true",
+			in:   Signup{AcceptedTerms: true, Newsletter: true, Admin: &no, Flags: []bool{true, false}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "Email"),
+				fieldError(&CrossFieldError{Field: "Newsletter", Err: ErrValidationEqField}, "Admin"),
+				fieldError(ErrRequired, "Flags", 1),
+			},
+		},
+		{
+			name: "false is empty",
+			in:   Signup{Email: "bob@example.com", Flags: []bool{true}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationBoolEq, "AcceptedTerms"),
+				fieldError(ErrRequired, "Newsletter"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
+		})
+	}
+}
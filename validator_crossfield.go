@@ -0,0 +1,158 @@
+package struct_validator
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	ErrValidationEqField  = errors.New("validation error, value isn't equal to field")
+	ErrValidationNeField  = errors.New("validation error, value is equal to field")
+	ErrValidationGtField  = errors.New("validation error, value isn't greater than field")
+	ErrValidationGteField = errors.New("validation error, value is less than field")
+	ErrValidationLtField  = errors.New("validation error, value isn't less than field")
+	ErrValidationLteField = errors.New("validation error, value is greater than field")
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// CrossFieldError is a failed comparison with another field, it wraps one of
+// the ErrValidation*Field errors.
+type CrossFieldError struct {
+	Field string // the other field as it's reported, e.g. "Period.Start"
+	Err   error
+}
+
+func (e *CrossFieldError) Error() string {
+	return e.Err.Error() + " " + e.Field
+}
+
+func (e *CrossFieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldComparisons are the cross-field operators with the error they report
+// and whether the result of comparing the value with the other field passes.
+var fieldComparisons = map[string]struct {
+	err  error
+	pass func(cmp int) bool
+}{
+	"eqfield":  {ErrValidationEqField, func(cmp int) bool { return cmp == 0 }},
+	"nefield":  {ErrValidationNeField, func(cmp int) bool { return cmp != 0 }},
+	"gtfield":  {ErrValidationGtField, func(cmp int) bool { return cmp > 0 }},
+	"gtefield": {ErrValidationGteField, func(cmp int) bool { return cmp >= 0 }},
+	"ltfield":  {ErrValidationLtField, func(cmp int) bool { return cmp < 0 }},
+	"ltefield": {ErrValidationLteField, func(cmp int) bool { return cmp <= 0 }},
+}
+
+// crossFieldRule compiles a comparison of a value of type t with the field of
+// parent named by the operand. A missing other field fails the comparison.
+// nameOf gives the names the other field is reported by.
+func crossFieldRule(c Condition, parent, t reflect.Type, nameOf func(reflect.StructField) string) (rule, error) {
+	op := fieldComparisons[c.operator]
+	if c.operand == "" {
+		return rule{}, ErrInvalidOperand
+	}
+	f, err := resolveField(parent, c.operand)
+	if err != nil {
+		return rule{}, err
+	}
+	compare, err := comparatorOf(t, indirectType(f.typ))
+	if err != nil {
+		return rule{}, err
+	}
+	names := make([]string, len(f.fields))
+	for i, sf := range f.fields {
+		names[i] = nameOf(sf)
+	}
+	field := strings.Join(names, ".")
+
+	return rule{c, func(fl FieldLevel) error {
+		other, ok := indirect(f.lookup(fl.Parent))
+		if ok && other.IsValid() {
+			if cmp, ok := compare(fl.Value, other); ok && op.pass(cmp) {
+				return nil
+			}
+		}
+		return &CrossFieldError{Field: field, Err: op.err}
+	}}, nil
+}
+
+// comparatorOf returns a func comparing values of types a and b, it reports
+// false when they are unordered, e.g. a NaN. Integers compare with integers
+// of the same signedness and floats with floats whatever the size. False is
+// less than true.
+func comparatorOf(a, b reflect.Type) (func(x, y reflect.Value) (int, bool), error) {
+	if a == timeType || b == timeType {
+		if a != b {
+			return nil, ErrUnsupType
+		}
+		return func(x, y reflect.Value) (int, bool) {
+			return x.Interface().(time.Time).Compare(y.Interface().(time.Time)), true
+		}, nil
+	}
+
+	switch ka, kb := kindClass(a), kindClass(b); {
+	case ka != kb:
+		return nil, ErrUnsupType
+	case ka == reflect.Int:
+		return func(x, y reflect.Value) (int, bool) { return compareOrdered(x.Int(), y.Int()), true }, nil
+	case ka == reflect.Uint:
+		return func(x, y reflect.Value) (int, bool) { return compareOrdered(x.Uint(), y.Uint()), true }, nil
+	case ka == reflect.Float64:
+		return func(x, y reflect.Value) (int, bool) {
+			fx, fy := x.Float(), y.Float()
+			if math.IsNaN(fx) || math.IsNaN(fy) {
+				return 0, false
+			}
+			return compareOrdered(fx, fy), true
+		}, nil
+	case ka == reflect.String:
+		return func(x, y reflect.Value) (int, bool) { return strings.Compare(x.String(), y.String()), true }, nil
+	case ka == reflect.Bool:
+		return func(x, y reflect.Value) (int, bool) {
+			return compareOrdered(boolInt(x.Bool()), boolInt(y.Bool())), true
+		}, nil
+	default:
+		return nil, ErrUnsupType
+	}
+}
+
+// kindClass groups the kinds comparable with each other.
+func kindClass(t reflect.Type) reflect.Kind {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	case reflect.String:
+		return reflect.String
+	case reflect.Bool:
+		return reflect.Bool
+	default:
+		return reflect.Invalid
+	}
+}
+
+func compareOrdered[T int64 | uint64 | float64](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
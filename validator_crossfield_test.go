@@ -0,0 +1,165 @@
+package struct_validator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCrossField(t *testing.T) {
+	type (
+		Period struct {
+			Start time.Time
+			End   time.Time `validate:"gtfield:Start"`
+		}
+
+		Form struct {
+			Password        string
+			PasswordConfirm string `validate:"eqfield:Password"`
+			Login           string `validate:"nefield:Password"`
+			MinAge          int8   `validate:"ltefield:MaxAge"`
+			MaxAge          int64  `validate:"gtefield:MinAge"`
+			Retries         uint   `validate:"ltfield:Limit"`
+			Limit           uint32
+			Price           float32 `validate:"gtfield:Cost"`
+			Cost            float64
+			Period          Period     `validate:"nested"`
+			Deadline        *time.Time `validate:"gtfield:Period.End"`
+			Steps           []int      `validate:"ltfield:MaxAge"`
+		}
+	)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	deadline := later.Add(time.Hour)
+	valid := Form{
+		Password:        "secret",
+		PasswordConfirm: "secret",
+		Login:           "bob",
+		MinAge:          18,
+		MaxAge:          65,
+		Retries:         2,
+		Limit:           3,
+		Price:           10.5,
+		Cost:            10,
+		Period:          Period{Start: now, End: later},
+		Deadline:        &deadline,
+		Steps:           []int{1, 64},
+	}
+
+	tests := []struct {
+		name        string
+		in          func(f Form) Form
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			in:          func(f Form) Form { return f },
+			expectedErr: nil,
+		},
+		{
+			name: "invalid",
+			in: func(f Form) Form {
+				f.PasswordConfirm = "secrets"
+				f.Login = "secret"
+				f.MinAge = 70
+				f.Retries = 3
+				f.Price = float32(math.NaN())
+				f.Period.End = now
+				f.Deadline = &now
+				f.Steps = []int{1, 65, 70}
+				return f
+			},
+			expectedErr: ValidationErrors{
+				fieldError(&CrossFieldError{Field: "Password", Err: ErrValidationEqField}, "PasswordConfirm"),
+				fieldError(&CrossFieldError{Field: "Password", Err: ErrValidationNeField}, "Login"),
+				fieldError(&CrossFieldError{Field: "MaxAge", Err: ErrValidationLteField}, "MinAge"),
+				fieldError(&CrossFieldError{Field: "MinAge", Err: ErrValidationGteField}, "MaxAge"),
+				fieldError(&CrossFieldError{Field: "Limit", Err: ErrValidationLtField}, "Retries"),
+				fieldError(&CrossFieldError{Field: "Cost", Err: ErrValidationGtField}, "Price"),
+				fieldError(&CrossFieldError{Field: "Start", Err: ErrValidationGtField}, "Period", "End"),
+				fieldError(&CrossFieldError{Field: "Period.End", Err: ErrValidationGtField}, "Deadline"),
+				fieldError(&CrossFieldError{Field: "MaxAge", Err: ErrValidationLtField}, "Steps", 1),
+				fieldError(&CrossFieldError{Field: "MaxAge", Err: ErrValidationLtField}, "Steps", 2),
+			},
+		},
+		{
+			name: "nil pointer to the value is skipped",
+			in: func(f Form) Form {
+				f.Deadline = nil
+				return f
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, Validate(tc.in(valid)))
+		})
+	}
+}
+
+func TestCrossFieldError(t *testing.T) {
+	type Range struct {
+		Start *int
+		End   int `validate:"gtfield:Start"`
+	}
+	start := 10
+
+	var ve ValidationErrors
+	require.ErrorAs(t, Validate(Range{Start: &start, End: 5}), &ve)
+	require.Len(t, ve, 1)
+	require.Equal(t, "End", ve[0].Field)
+	require.ErrorIs(t, ve[0].Err, ErrValidationGtField)
+	require.Equal(t, "validation error, value isn't greater than field Start", ve[0].Err.Error())
+
+	// There's nothing to compare with.
+	require.ErrorAs(t, Validate(Range{End: 5}), &ve)
+	require.ErrorIs(t, ve[0].Err, ErrValidationGtField)
+
+	type Booking struct {
+		Period struct {
+			Start int `json:"start"`
+		} `json:"period"`
+		Deadline int `json:"deadline" validate:"gtfield:Period.Start"`
+	}
+	require.Equal(t, ValidationErrors{
+		fieldError(&CrossFieldError{Field: "period.start", Err: ErrValidationGtField}, "deadline"),
+	}, New(WithFieldNameTag("json")).Validate(Booking{}))
+}
+
+func TestCrossFieldRuleErrors(t *testing.T) {
+	type (
+		Unknown struct {
+			End int `validate:"gtfield:Start"`
+		}
+		NoOperand struct {
+			End int `validate:"gtfield"`
+		}
+		Mismatch struct {
+			Start string
+			End   int `validate:"gtfield:Start"`
+		}
+		Signedness struct {
+			Start uint
+			End   int `validate:"gtfield:Start"`
+		}
+		NotTime struct {
+			Start int64
+			End   time.Time `validate:"gtfield:Start"`
+		}
+		Unsupported struct {
+			Start []int
+			End   []int `validate:"dive|eqfield:Start"`
+		}
+	)
+
+	require.ErrorIs(t, Validate(Unknown{}), ErrInvalidOperand)
+	require.ErrorIs(t, Validate(NoOperand{}), ErrInvalidOperand)
+	for _, s := range []interface{}{Mismatch{}, Signedness{}, NotTime{}, Unsupported{}} {
+		require.ErrorIs(t, Validate(s), ErrUnsupType, "%T", s)
+	}
+}
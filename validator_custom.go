@@ -0,0 +1,67 @@
+package struct_validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var ErrValidationName = errors.New("invalid validation name")
+
+// FieldLevel is the value a condition is checked against.
+type FieldLevel struct {
+	Context context.Context
+	Value   reflect.Value // pointers are dereferenced
+	Operand string
+	Parent  reflect.Value // struct holding the field
+}
+
+// ValidationFunc checks a custom condition. It returns nil when the value is
+// valid and otherwise the error reported as ValidationError.Err.
+type ValidationFunc func(fl FieldLevel) error
+
+// reserved operators are handled by the validator itself and can't be
+// registered, as are the presence ones.
+var reserved = map[string]bool{
+	"nested":  true,
+	"dive":    true,
+	"keys":    true,
+	"endkeys": true,
+}
+
+// RegisterValidation registers fn for the operator name on the default Validator.
+func RegisterValidation(name string, fn ValidationFunc) error {
+	return defaultValidator.RegisterValidation(name, fn)
+}
+
+// RegisterValidation registers fn for the operator name. It takes precedence
+// over a built-in operator of the same name for every kind.
+func (v *Validator) RegisterValidation(name string, fn ValidationFunc) error {
+	if name == "" || reserved[name] || presence[name] || fn == nil || strings.HasPrefix(name, negation) ||
+		strings.ContainsAny(name, openGroup+closeGroup) ||
+		strings.Contains(name, v.syntax.operand) || strings.Contains(name, v.syntax.and) ||
+		strings.Contains(name, v.syntax.or) {
+		return ErrValidationName
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.funcs == nil {
+		v.funcs = map[string]ValidationFunc{}
+	}
+	v.funcs[name] = fn
+
+	// Cached plans could have been compiled with a built-in of the same name.
+	v.plans.Range(func(t, _ interface{}) bool {
+		v.plans.Delete(t)
+		return true
+	})
+	return nil
+}
+
+// customRule must be called with v.mu held.
+func (v *Validator) customRule(c Condition) (rule, bool) {
+	fn, ok := v.funcs[c.operator]
+	return rule{c, fn}, ok
+}
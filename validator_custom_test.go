@@ -0,0 +1,151 @@
+package struct_validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errSKU = errors.New("validation error, not a SKU")
+
+type ctxKey struct{}
+
+func TestRegisterValidation(t *testing.T) {
+	skuRe := regexp.MustCompile(`^[A-Z]{3}-\d+$`)
+	sku := func(fl FieldLevel) error {
+		if !skuRe.MatchString(fl.Value.String()) {
+			return errSKU
+		}
+		return nil
+	}
+	prefix := func(fl FieldLevel) error {
+		if fl.Value.String()[:len(fl.Operand)] != fl.Operand {
+			return errSKU
+		}
+		return nil
+	}
+	notOwner := func(fl FieldLevel) error {
+		if fl.Value.String() == fl.Parent.FieldByName("Owner").String() {
+			return errors.New("validation error, same as owner")
+		}
+		return nil
+	}
+	tenant := func(fl FieldLevel) error {
+		if fl.Value.String() != fl.Context.Value(ctxKey{}) {
+			return errors.New("validation error, foreign tenant")
+		}
+		return nil
+	}
+
+	type Product struct {
+		SKU     string   `validate:"len:7|sku|prefix:ABC"`
+		Related []string `validate:"sku"`
+		Owner   string
+		Editor  string `validate:"notowner"`
+		Tenant  string `validate:"tenant"`
+	}
+
+	v := New()
+	require.NoError(t, v.RegisterValidation("sku", sku))
+	require.NoError(t, v.RegisterValidation("prefix", prefix))
+	require.NoError(t, v.RegisterValidation("notowner", notOwner))
+	require.NoError(t, v.RegisterValidation("tenant", tenant))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "acme")
+	require.NoError(t, v.ValidateContext(ctx, Product{
+		SKU:     "ABC-123",
+		Related: []string{"XYZ-1"},
+		Owner:   "bob",
+		Editor:  "alice",
+		Tenant:  "acme",
+	}))
+
+	err := v.ValidateContext(ctx, Product{
+		SKU:     "XYZ-1234",
+		Related: []string{"XYZ-1", "xyz"},
+		Owner:   "bob",
+		Editor:  "bob",
+		Tenant:  "other",
+	})
+	var verr ValidationErrors
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr, 5)
+	require.Equal(t, fieldError(ErrValidationStrLen, "SKU"), verr[0])
+	require.Equal(t, fieldError(errSKU, "SKU"), verr[1])
+	require.Equal(t, fieldError(errSKU, "Related", 1), verr[2])
+	require.Equal(t, "Editor", verr[3].Field)
+	require.Equal(t, "Tenant", verr[4].Field)
+}
+
+func TestRegisterValidationOverride(t *testing.T) {
+	type Version struct {
+		Number int `validate:"semver"`
+		Name   string
+	}
+
+	v := New()
+	require.Equal(t, ErrUnsupCondition, v.Validate(Version{}))
+
+	require.NoError(t, v.RegisterValidation("semver", func(fl FieldLevel) error {
+		if fl.Value.Int() < 1 {
+			return ErrValidationIntMin
+		}
+		return nil
+	}))
+	require.Equal(t, ValidationErrors{fieldError(ErrValidationIntMin, "Number")}, v.Validate(Version{}))
+
+	require.NoError(t, v.RegisterValidation("len", func(fl FieldLevel) error { return nil }))
+	require.NoError(t, v.Validate(App{Version: "release"}))
+	require.Error(t, Validate(App{Version: "release"}))
+}
+
+func TestRegisterValidationErrors(t *testing.T) {
+	fn := func(fl FieldLevel) error { return nil }
+	tests := []struct {
+		name string
+		op   string
+		fn   ValidationFunc
+	}{
+		{name: "empty name", op: "", fn: fn},
+		{name: "reserved name", op: "nested", fn: fn},
+		{name: "presence name", op: "required_if", fn: fn},
+		{name: "separator in name", op: "a|b", fn: fn},
+		{name: "negated name", op: "!custom", fn: fn},
+		{name: "operand in name", op: "a:b", fn: fn},
+		{name: "nil func", op: "custom", fn: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, ErrValidationName, New().RegisterValidation(tc.op, tc.fn))
+		})
+	}
+}
+
+func TestRegisterValidationDefault(t *testing.T) {
+	type Even struct {
+		N int `validate:"test_even"`
+	}
+
+	// Register on a fresh default validator, so that no state leaks into the
+	// other tests.
+	saved := defaultValidator
+	defaultValidator = New()
+	t.Cleanup(func() { defaultValidator = saved })
+
+	require.NoError(t, RegisterValidation("test_even", func(fl FieldLevel) error {
+		if fl.Value.Int()%2 != 0 {
+			return ErrValidationIntIn
+		}
+		return nil
+	}))
+	require.NoError(t, Validate(Even{N: 2}))
+	require.Equal(t, ValidationErrors{fieldError(ErrValidationIntIn, "N")}, Validate(&Even{N: 3}))
+
+	_, err := New().planOf(reflect.TypeOf(Even{}))
+	require.Equal(t, ErrUnsupCondition, err)
+}
@@ -0,0 +1,89 @@
+package struct_validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var (
+	ErrValidationFloatMin = errors.New("validation error, value less than expected")
+	ErrValidationFloatMax = errors.New("validation error, value greater than expected")
+	ErrValidationFloatIn  = errors.New("validation error, value dosen't match a subset of float")
+)
+
+// floatRule compiles c for a float kind of the given bit size. Operands are
+// rounded to that size, so float32 fields compare against float32 operands.
+func floatRule(c Condition, bits int) (rule, error) {
+	switch c.operator {
+	case "min":
+		m, err := parseFloat(c.operand, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateFloatMin(fl.Value.Float(), m) }}, nil
+	case "max":
+		m, err := parseFloat(c.operand, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateFloatMax(fl.Value.Float(), m) }}, nil
+	case "in":
+		in, err := parseFloatIn(c.params, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateFloatIn(fl.Value.Float(), in) }}, nil
+	default:
+		return rule{}, ErrUnsupCondition
+	}
+}
+
+func parseFloat(s string, bits int) (float64, error) {
+	f, err := strconv.ParseFloat(s, bits)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(f) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidOperand, s)
+	}
+	return f, nil
+}
+
+func parseFloatIn(in []string, bits int) ([]float64, error) {
+	subset := []float64{}
+	for _, fs := range in {
+		f, err := parseFloat(fs, bits)
+		if err != nil {
+			return nil, err
+		}
+		subset = append(subset, f)
+	}
+	return subset, nil
+}
+
+// NaN compares false against anything, so the checks are written to reject it.
+
+func validateFloatMin(field float64, min float64) error {
+	if !(field >= min) {
+		return ErrValidationFloatMin
+	}
+	return nil
+}
+
+func validateFloatMax(field float64, max float64) error {
+	if !(field <= max) {
+		return ErrValidationFloatMax
+	}
+	return nil
+}
+
+func validateFloatIn(field float64, in []float64) error {
+	for _, f := range in {
+		if field == f {
+			return nil
+		}
+	}
+	return ErrValidationFloatIn
+}
@@ -0,0 +1,104 @@
+package struct_validator
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFloatField(t *testing.T) {
+	type in struct {
+		tag   string
+		value interface{}
+		name  string
+	}
+	tests := []struct {
+		name  string
+		input in
+		err   error
+	}{
+		{
+			name:  "validate min max",
+			input: in{tag: "min:0.5|max:99.99", value: 10.25, name: "Price"},
+			err:   nil,
+		},
+		{
+			name:  "validate err min max",
+			input: in{tag: "min:0.5|max:99.99", value: []float64{0.25, 100}, name: "Price"},
+			err: ValidationErrors{
+				fieldError(ErrValidationFloatMin, "Price", 0),
+				fieldError(ErrValidationFloatMax, "Price", 1),
+			},
+		},
+		{
+			name:  "validate in",
+			input: in{tag: "in:0.1,1e3,-2", value: []float64{0.1, 1000, -2}, name: "Rate"},
+			err:   nil,
+		},
+		{
+			name:  "validate err in",
+			input: in{tag: "in:0.1,0.2", value: 0.3, name: "Rate"},
+			err: ValidationErrors{
+				fieldError(ErrValidationFloatIn, "Rate"),
+			},
+		},
+		{
+			name:  "float32 operand rounding",
+			input: in{tag: "in:0.1|max:0.1", value: float32(0.1), name: "Rate"},
+			err:   nil,
+		},
+		{
+			name:  "NaN value",
+			input: in{tag: "min:0|max:1", value: math.NaN(), name: "Rate"},
+			err: ValidationErrors{
+				fieldError(ErrValidationFloatMin, "Rate"),
+				fieldError(ErrValidationFloatMax, "Rate"),
+			},
+		},
+		{
+			name:  "infinite operand",
+			input: in{tag: "max:+Inf", value: math.MaxFloat64, name: "Rate"},
+			err:   nil,
+		},
+		{
+			name:  "NaN operand",
+			input: in{tag: "min:NaN", value: 1.0, name: "Rate"},
+			err:   ErrInvalidOperand,
+		},
+		{
+			name:  "strconv err",
+			input: in{tag: "max:one", value: 1.0, name: "Rate"},
+			err:   strconv.ErrSyntax,
+		},
+		{
+			name:  "float32 range err",
+			input: in{tag: "max:1e39", value: float32(1), name: "Rate"},
+			err:   strconv.ErrRange,
+		},
+		{
+			name:  "unsupported condition",
+			input: in{tag: "len:1", value: 1.0, name: "Rate"},
+			err:   ErrUnsupCondition,
+		},
+	}
+
+	var verr ValidationErrors
+	var serr *strconv.NumError
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTagged(tc.input.name, tc.input.tag, tc.input.value)
+			if errors.As(err, &verr) {
+				require.Equal(t, tc.err, verr)
+				return
+			}
+			if errors.As(err, &serr) {
+				require.Equal(t, tc.err, serr.Err)
+				return
+			}
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package struct_validator
+
+import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrValidationUUID     = errors.New("validation error, string isn't a UUID")
+	ErrValidationEmail    = errors.New("validation error, string isn't an email address")
+	ErrValidationURL      = errors.New("validation error, string isn't a URL")
+	ErrValidationURI      = errors.New("validation error, string isn't a URI")
+	ErrValidationIP       = errors.New("validation error, string isn't an IP address")
+	ErrValidationIPv4     = errors.New("validation error, string isn't an IPv4 address")
+	ErrValidationIPv6     = errors.New("validation error, string isn't an IPv6 address")
+	ErrValidationCIDR     = errors.New("validation error, string isn't a CIDR prefix")
+	ErrValidationHostname = errors.New("validation error, string isn't a hostname")
+	ErrValidationMAC      = errors.New("validation error, string isn't a MAC address")
+	ErrValidationBase64   = errors.New("validation error, string isn't base64")
+	ErrValidationHex      = errors.New("validation error, string isn't hexadecimal")
+	ErrValidationSemver   = errors.New("validation error, string isn't a semantic version")
+	ErrValidationE164     = errors.New("validation error, string isn't an E.164 phone number")
+)
+
+var (
+	// https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
+	semverRe = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	e164Re = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+)
+
+// formats are the string formats checked by a single func, their operators
+// take no operand.
+var formats = map[string]func(s string) error{
+	"email":    validateEmail,
+	"url":      validateURL,
+	"uri":      validateURI,
+	"ip":       validateIP,
+	"ipv4":     validateIPv4,
+	"ipv6":     validateIPv6,
+	"cidr":     validateCIDR,
+	"hostname": validateHostname,
+	"mac":      validateMAC,
+	"base64":   validateBase64,
+	"hex":      validateHex,
+	"semver":   validateSemver,
+	"e164":     validateE164,
+}
+
+// formatRule compiles c for a string if it's a format operator.
+func formatRule(c Condition) (rule, error) {
+	if c.operator == "uuid" {
+		version := 0
+		if c.operand != "" {
+			var err error
+			if version, err = strconv.Atoi(c.operand); err != nil {
+				return rule{}, err
+			}
+			if version < 1 || version > 8 {
+				return rule{}, ErrInvalidOperand
+			}
+		}
+		return rule{c, func(fl FieldLevel) error { return validateUUID(fl.Value.String(), version) }}, nil
+	}
+
+	validate, ok := formats[c.operator]
+	if !ok {
+		return rule{}, ErrUnsupCondition
+	}
+	if c.operand != "" {
+		return rule{}, ErrInvalidOperand
+	}
+	return rule{c, func(fl FieldLevel) error { return validate(fl.Value.String()) }}, nil
+}
+
+// validateUUID accepts the canonical 8-4-4-4-12 form in any case. A non-zero
+// version also requires the RFC 4122 variant.
+func validateUUID(s string, version int) error {
+	if len(s) != 36 {
+		return ErrValidationUUID
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return ErrValidationUUID
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return ErrValidationUUID
+			}
+		}
+	}
+	if version == 0 {
+		return nil
+	}
+	if s[14] != "0123456789"[version] || strings.IndexByte("89abAB", s[19]) < 0 {
+		return ErrValidationUUID
+	}
+	return nil
+}
+
+// validateEmail accepts a bare RFC 5322 address, without a display name.
+func validateEmail(s string) error {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return ErrValidationEmail
+	}
+	return nil
+}
+
+// validateURL accepts an absolute URL with a host, e.g. "https://example.com".
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ErrValidationURL
+	}
+	return nil
+}
+
+// validateURI accepts any absolute URI, e.g. "mailto:bob@example.com".
+func validateURI(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" {
+		return ErrValidationURI
+	}
+	return nil
+}
+
+func validateIP(s string) error {
+	if _, err := netip.ParseAddr(s); err != nil {
+		return ErrValidationIP
+	}
+	return nil
+}
+
+func validateIPv4(s string) error {
+	if ip, err := netip.ParseAddr(s); err != nil || !ip.Is4() {
+		return ErrValidationIPv4
+	}
+	return nil
+}
+
+func validateIPv6(s string) error {
+	if ip, err := netip.ParseAddr(s); err != nil || !ip.Is6() {
+		return ErrValidationIPv6
+	}
+	return nil
+}
+
+func validateCIDR(s string) error {
+	if _, err := netip.ParsePrefix(s); err != nil {
+		return ErrValidationCIDR
+	}
+	return nil
+}
+
+// validateHostname accepts an RFC 1123 hostname, optionally ending with a dot.
+func validateHostname(s string) error {
+	if len(s) > 0 && s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	if len(s) == 0 || len(s) > 253 {
+		return ErrValidationHostname
+	}
+	label := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '.':
+			if label == 0 || s[i-1] == '-' {
+				return ErrValidationHostname
+			}
+			label = 0
+			continue
+		case c == '-':
+			if label == 0 {
+				return ErrValidationHostname
+			}
+		case !isAlnum(c):
+			return ErrValidationHostname
+		}
+		if label++; label > 63 {
+			return ErrValidationHostname
+		}
+	}
+	if s[len(s)-1] == '-' {
+		return ErrValidationHostname
+	}
+	return nil
+}
+
+// validateMAC accepts the IEEE 802 MAC-48, EUI-48, EUI-64 and 20-octet
+// addresses in the forms net.ParseMAC does.
+func validateMAC(s string) error {
+	if _, err := net.ParseMAC(s); err != nil {
+		return ErrValidationMAC
+	}
+	return nil
+}
+
+// validateBase64 accepts the padded standard encoding.
+func validateBase64(s string) error {
+	if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+		return ErrValidationBase64
+	}
+	return nil
+}
+
+// validateHex accepts a non-empty string of hex digits without a "0x" prefix.
+func validateHex(s string) error {
+	if s == "" {
+		return ErrValidationHex
+	}
+	for i := 0; i < len(s); i++ {
+		if !isHexDigit(s[i]) {
+			return ErrValidationHex
+		}
+	}
+	return nil
+}
+
+func validateSemver(s string) error {
+	if !semverRe.MatchString(s) {
+		return ErrValidationSemver
+	}
+	return nil
+}
+
+// validateE164 accepts a "+" followed by up to 15 digits, e.g. "+14155552671".
+func validateE164(s string) error {
+	if !e164Re.MatchString(s) {
+		return ErrValidationE164
+	}
+	return nil
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func isAlnum(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
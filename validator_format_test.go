@@ -0,0 +1,120 @@
+package struct_validator
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFormats(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value string
+		err   error
+	}{
+		{tag: "uuid", value: "7f0e3265-ca96-4b33-8858-fef9696cc71b", err: nil},
+		{tag: "uuid", value: "7F0E3265-CA96-4B33-8858-FEF9696CC71B", err: nil},
+		{tag: "uuid", value: "7f0e3265ca964b338858fef9696cc71b", err: ErrValidationUUID},
+		{tag: "uuid", value: "7f0e3265-ca96-4b33-8858-fef9696cc71g", err: ErrValidationUUID},
+		{tag: "uuid", value: "7f0e3265-ca96-4b33-8858-fef9", err: ErrValidationUUID},
+		{tag: "uuid:4", value: "7f0e3265-ca96-4b33-8858-fef9696cc71b", err: nil},
+		{tag: "uuid:4", value: "7f0e3265-ca96-1b33-8858-fef9696cc71b", err: ErrValidationUUID},
+		{tag: "uuid:4", value: "7f0e3265-ca96-4b33-c858-fef9696cc71b", err: ErrValidationUUID},
+		{tag: "uuid:7", value: "018f3c6e-7b1a-7cde-9f00-1234567890ab", err: nil},
+		{tag: "email", value: "bob@example.com", err: nil},
+		{tag: "email", value: "bob.smith+tag@mail.example.co.uk", err: nil},
+		{tag: "email", value: "Bob <bob@example.com>", err: ErrValidationEmail},
+		{tag: "email", value: "bob@", err: ErrValidationEmail},
+		{tag: "email", value: "", err: ErrValidationEmail},
+		{tag: "url", value: "https://example.com/path?q=1", err: nil},
+		{tag: "url", value: "/relative/path", err: ErrValidationURL},
+		{tag: "url", value: "mailto:bob@example.com", err: ErrValidationURL},
+		{tag: "url", value: "http://[::1", err: ErrValidationURL},
+		{tag: "uri", value: "mailto:bob@example.com", err: nil},
+		{tag: "uri", value: "urn:isbn:0451450523", err: nil},
+		{tag: "uri", value: "example.com", err: ErrValidationURI},
+		{tag: "ip", value: "192.168.0.1", err: nil},
+		{tag: "ip", value: "2001:db8::1", err: nil},
+		{tag: "ip", value: "256.0.0.1", err: ErrValidationIP},
+		{tag: "ipv4", value: "10.0.0.1", err: nil},
+		{tag: "ipv4", value: "::1", err: ErrValidationIPv4},
+		{tag: "ipv6", value: "fe80::1", err: nil},
+		{tag: "ipv6", value: "10.0.0.1", err: ErrValidationIPv6},
+		{tag: "cidr", value: "10.0.0.0/8", err: nil},
+		{tag: "cidr", value: "2001:db8::/32", err: nil},
+		{tag: "cidr", value: "10.0.0.0/33", err: ErrValidationCIDR},
+		{tag: "cidr", value: "10.0.0.0", err: ErrValidationCIDR},
+		{tag: "hostname", value: "example.com", err: nil},
+		{tag: "hostname", value: "my-host.example.com.", err: nil},
+		{tag: "hostname", value: "localhost", err: nil},
+		{tag: "hostname", value: "-host.example.com", err: ErrValidationHostname},
+		{tag: "hostname", value: "host-.example.com", err: ErrValidationHostname},
+		{tag: "hostname", value: "host..example.com", err: ErrValidationHostname},
+		{tag: "hostname", value: "host_name.com", err: ErrValidationHostname},
+		{tag: "hostname", value: strings.Repeat("a", 64) + ".com", err: ErrValidationHostname},
+		{tag: "hostname", value: ".", err: ErrValidationHostname},
+		{tag: "mac", value: "00:1a:2b:3c:4d:5e", err: nil},
+		{tag: "mac", value: "00-1A-2B-3C-4D-5E", err: nil},
+		{tag: "mac", value: "00:1a:2b:3c:4d", err: ErrValidationMAC},
+		{tag: "base64", value: "aGVsbG8=", err: nil},
+		{tag: "base64", value: "aGVsbG8", err: ErrValidationBase64},
+		{tag: "base64", value: "aGVs*G8=", err: ErrValidationBase64},
+		{tag: "hex", value: "deadBEEF01", err: nil},
+		{tag: "hex", value: "abc", err: nil},
+		{tag: "hex", value: "0xabc", err: ErrValidationHex},
+		{tag: "hex", value: "", err: ErrValidationHex},
+		{tag: "semver", value: "1.2.3", err: nil},
+		{tag: "semver", value: "1.0.0-alpha.1+build.5", err: nil},
+		{tag: "semver", value: "v1.2.3", err: ErrValidationSemver},
+		{tag: "semver", value: "01.2.3", err: ErrValidationSemver},
+		{tag: "e164", value: "+14155552671", err: nil},
+		{tag: "e164", value: "14155552671", err: ErrValidationE164},
+		{tag: "e164", value: "+0123", err: ErrValidationE164},
+		{tag: "e164", value: "+1234567890123456", err: ErrValidationE164},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag+" "+tc.value, func(t *testing.T) {
+			require.Equal(t, tc.err, checkRule(tc.tag, tc.value))
+		})
+	}
+}
+
+func TestFormatRuleErrors(t *testing.T) {
+	require.ErrorIs(t, checkRule("uuid:x", ""), strconv.ErrSyntax)
+	require.ErrorIs(t, checkRule("uuid:9", ""), ErrInvalidOperand)
+	require.ErrorIs(t, checkRule("email:strict", ""), ErrInvalidOperand)
+	require.ErrorIs(t, checkRule("email", 1), ErrUnsupCondition)
+	require.ErrorIs(t, checkRule("postcode", ""), ErrUnsupCondition)
+}
+
+func TestValidateFormatsStruct(t *testing.T) {
+	type Server struct {
+		ID      string   `validate:"uuid:4"`
+		Host    string   `validate:"hostname||ip"`
+		Admin   string   `validate:"omitempty|email"`
+		Version string   `validate:"semver"`
+		Phones  []string `validate:"e164"`
+	}
+
+	require.NoError(t, Validate(Server{
+		ID:      "7f0e3265-ca96-4b33-8858-fef9696cc71b",
+		Host:    "10.0.0.1",
+		Version: "0.1.0",
+		Phones:  []string{"+14155552671"},
+	}))
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationUUID, "ID"),
+		fieldError(ErrValidationEmail, "Admin"),
+		fieldError(ErrValidationSemver, "Version"),
+		fieldError(ErrValidationE164, "Phones", 1),
+	}, Validate(Server{
+		ID:      "7f0e3265-ca96-1b33-8858-fef9696cc71b",
+		Host:    "example.com",
+		Admin:   "admin",
+		Version: "1.0",
+		Phones:  []string{"+14155552671", "555-0100"},
+	}))
+}
@@ -0,0 +1,164 @@
+package struct_validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// Validatable is implemented by types that check themselves beyond their
+// validate tags. Validate is called on a validated value of the type, on the
+// struct passed to Validate as well as on its fields and elements. A returned
+// ValidationErrors is merged into the errors of the call with its paths
+// prefixed by the path of the value, any other error is reported as the
+// failure of the value itself. A Validate promoted from an embedded field is
+// skipped while that field is a nil pointer or interface.
+//
+// Validate mustn't validate its receiver with a Validator, as that calls it
+// again.
+type Validatable interface {
+	Validate() error
+}
+
+// ContextValidatable is Validatable taking the context of the validation. It's
+// called instead of Validate if a type implements both.
+type ContextValidatable interface {
+	ValidateContext(ctx context.Context) error
+}
+
+var (
+	validatableType        = reflect.TypeOf((*Validatable)(nil)).Elem()
+	contextValidatableType = reflect.TypeOf((*ContextValidatable)(nil)).Elem()
+)
+
+// hook is the method validating the values of a type.
+type hook struct {
+	context bool  // ValidateContext instead of Validate
+	embed   []int // index of the embedded field it may be promoted from
+}
+
+// hookOf returns the hook of values of type t, or pointers to them, nil if
+// there is none. The hook of an interface is found on its dynamic type.
+func hookOf(t reflect.Type) *hook {
+	t = indirectType(t)
+	if t.Kind() == reflect.Interface {
+		if t.Implements(validatableType) || t.Implements(contextValidatableType) {
+			return &hook{}
+		}
+		return nil
+	}
+	switch pt := reflect.PtrTo(t); {
+	case pt.Implements(contextValidatableType):
+		return &hook{context: true, embed: promotedFrom(t, "ValidateContext", map[reflect.Type]bool{})}
+	case pt.Implements(validatableType):
+		return &hook{embed: promotedFrom(t, "Validate", map[reflect.Type]bool{})}
+	default:
+		return nil
+	}
+}
+
+// promotedFrom returns the index of the embedded field the method name of the
+// struct type t would be promoted from, following the embedded fields down to
+// the type declaring it. It's nil if no embedded field has the method, or if
+// several have it at the same depth, as then t must declare it. seen holds
+// the types being searched, to stop on cycles.
+func promotedFrom(t reflect.Type, name string, seen map[reflect.Type]bool) []int {
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	var found []int
+	ambiguous := false
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.Anonymous || !hasMethod(sf.Type, name) {
+			continue
+		}
+		index := append([]int{i}, promotedFrom(indirectType(sf.Type), name, seen)...)
+		switch {
+		case found == nil || len(index) < len(found):
+			found, ambiguous = index, false
+		case len(index) == len(found):
+			ambiguous = true
+		}
+	}
+	if ambiguous {
+		return nil
+	}
+	return found
+}
+
+// hasMethod reports whether the method set of a field of type t, taken by
+// its address, has the method name.
+func hasMethod(t reflect.Type, name string) bool {
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+		t = reflect.PtrTo(t)
+	}
+	_, ok := t.MethodByName(name)
+	return ok
+}
+
+// hasHooks reports whether values of type t or their elements have a hook,
+// so that a field of the type is validated even without a tag.
+func hasHooks(t reflect.Type) bool {
+	if hookOf(t) != nil {
+		return true
+	}
+	switch t = indirectType(t); t.Kind() { //nolint:exhaustive
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return hookOf(t.Elem()) != nil
+	default:
+		return false
+	}
+}
+
+// validateHook calls h on v, found at path. A ValidationErrors it returns is
+// merged with its paths prefixed by path, any other error is reported at
+// path. A value that isn't addressable is copied, so that methods with a
+// pointer receiver are called too. Values of unexported fields are skipped.
+func (w *walker) validateHook(v reflect.Value, h *hook, path Path) error {
+	if !v.CanInterface() {
+		return nil
+	}
+	if h.embed != nil {
+		e, err := v.FieldByIndexErr(h.embed)
+		if err != nil {
+			return nil
+		}
+		if _, ok := indirect(e); !ok {
+			return nil
+		}
+	}
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+
+	var err error
+	if h.context {
+		err = v.Addr().Interface().(ContextValidatable).ValidateContext(w.ctx)
+	} else {
+		err = v.Addr().Interface().(Validatable).Validate()
+	}
+	if err == nil {
+		return nil
+	}
+
+	var ve ValidationErrors
+	if !errors.As(err, &ve) {
+		return w.fail(path, err)
+	}
+	for _, e := range ve {
+		elemPath := e.Path
+		if elemPath == nil && e.Field != "" {
+			elemPath = Path{{Name: e.Field}}
+		}
+		if err := w.fail(append(path, elemPath...), e.Err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package struct_validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errRole   = errors.New("unknown role")
+	errPeriod = errors.New("period ends before it starts")
+	errTenant = errors.New("tenant mismatch")
+)
+
+type MemberRole string
+
+func (r MemberRole) Validate() error {
+	switch r {
+	case "admin", "user":
+		return nil
+	default:
+		return errRole
+	}
+}
+
+type Period struct {
+	From int `validate:"min:0"`
+	To   int
+}
+
+func (p *Period) Validate() error {
+	if p.To < p.From {
+		return ValidationErrors{newValidationError(Path{{Name: "To"}}, errPeriod)}
+	}
+	return nil
+}
+
+type tenantKey struct{}
+
+type Tenant string
+
+func (t Tenant) Validate() error {
+	panic("ValidateContext must be preferred")
+}
+
+func (t Tenant) ValidateContext(ctx context.Context) error {
+	if want, _ := ctx.Value(tenantKey{}).(string); want != string(t) {
+		return errTenant
+	}
+	return nil
+}
+
+func TestValidateHooks(t *testing.T) {
+	type Member struct {
+		Role   MemberRole `validate:"required"`
+		Roles  []MemberRole
+		Byname map[string]MemberRole `validate:"maxlen:2"`
+		Period Period
+		Leave  *Period `validate:"omitempty|nested"`
+		Tenant Tenant
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	tests := []struct {
+		name        string
+		in          interface{}
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			in: Member{
+				Role:   "admin",
+				Roles:  []MemberRole{"user"},
+				Byname: map[string]MemberRole{"bob": "user"},
+				Period: Period{From: 1, To: 2},
+				Tenant: "acme",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid",
+			in: &Member{
+				Role:   "root",
+				Roles:  []MemberRole{"user", "guest"},
+				Byname: map[string]MemberRole{"bob": "user", "eve": "spy"},
+				Period: Period{From: 3, To: 2},
+				Leave:  &Period{From: -1, To: -2},
+				Tenant: "globex",
+			},
+			expectedErr: ValidationErrors{
+				fieldError(errRole, "Role"),
+				fieldError(errRole, "Roles", 1),
+				fieldError(errRole, "Byname", PathElem{Key: "eve"}),
+				fieldError(errPeriod, "Period", "To"),
+				fieldError(ErrValidationIntMin, "Leave", "From"),
+				fieldError(errPeriod, "Leave", "To"),
+				fieldError(errTenant, "Tenant"),
+			},
+		},
+		{
+			name:        "empty required",
+			in:          Member{Tenant: "acme"},
+			expectedErr: ValidationErrors{fieldError(ErrRequired, "Role")},
+		},
+		{
+			name:        "top level",
+			in:          &Period{From: 1},
+			expectedErr: ValidationErrors{fieldError(errPeriod, "To")},
+		},
+		{
+			name:        "top level value",
+			in:          Period{From: -1, To: -2},
+			expectedErr: ValidationErrors{fieldError(ErrValidationIntMin, "From"), fieldError(errPeriod, "To")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, ValidateContext(ctx, tc.in))
+		})
+	}
+}
+
+func TestValidateHookFailFast(t *testing.T) {
+	type Team struct {
+		Roles []MemberRole
+	}
+
+	v := New(WithFailFast())
+	require.Equal(t, ValidationErrors{fieldError(errRole, "Roles", 0)}, v.Validate(Team{Roles: []MemberRole{"a", "b"}}))
+}
+
+func TestValidateHookInterface(t *testing.T) {
+	type Grant struct {
+		Role  Validatable
+		Extra interface{} `validate:"omitempty"`
+	}
+
+	require.NoError(t, Validate(Grant{Role: MemberRole("admin"), Extra: MemberRole("user")}))
+	require.Equal(t, ValidationErrors{
+		fieldError(errRole, "Role"),
+		fieldError(errRole, "Extra"),
+	}, Validate(Grant{Role: MemberRole("root"), Extra: MemberRole("root")}))
+}
+
+var errInner = errors.New("inner is invalid")
+
+type Inner struct {
+	Valid bool
+}
+
+func (i Inner) Validate() error {
+	if !i.Valid {
+		return errInner
+	}
+	return nil
+}
+
+type inner struct {
+	Valid bool
+}
+
+func (i inner) Validate() error {
+	if !i.Valid {
+		return errInner
+	}
+	return nil
+}
+
+func TestValidateHookEmbedded(t *testing.T) {
+	type (
+		Outer struct {
+			*Inner
+		}
+		outerUnexported struct {
+			*inner
+		}
+		Deep struct {
+			Outer
+		}
+		Both struct {
+			Inner
+			inner
+		}
+		Own struct {
+			*Inner
+			Inner2 Inner
+		}
+	)
+
+	tests := []struct {
+		name        string
+		in          interface{}
+		expectedErr error
+	}{
+		{name: "nil pointer", in: Outer{}, expectedErr: nil},
+		{name: "nil unexported pointer", in: outerUnexported{}, expectedErr: nil},
+		{name: "nil deep pointer", in: &Deep{}, expectedErr: nil},
+		{name: "pointer", in: Outer{Inner: &Inner{}}, expectedErr: ValidationErrors{fieldError(errInner)}},
+		{name: "valid pointer", in: &Outer{Inner: &Inner{Valid: true}}, expectedErr: nil},
+		{name: "unexported pointer", in: outerUnexported{inner: &inner{}}, expectedErr: ValidationErrors{fieldError(errInner)}},
+		{name: "deep pointer", in: Deep{Outer{Inner: &Inner{}}}, expectedErr: ValidationErrors{fieldError(errInner)}},
+		{name: "ambiguous", in: Both{}, expectedErr: nil},
+		{name: "field", in: Own{Inner2: Inner{}}, expectedErr: ValidationErrors{fieldError(errInner, "Inner2")}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
+		})
+	}
+}
+
+func TestValidateHookMessage(t *testing.T) {
+	type Order struct {
+		Inner
+		ID int `validate:"min:1"`
+	}
+
+	require.EqualError(t, Validate(Order{ID: 1}), errInner.Error())
+	require.EqualError(t, Validate(struct{ Order Order }{Order{ID: 1}}), "Order: "+errInner.Error())
+}
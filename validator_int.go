@@ -3,7 +3,6 @@ package struct_validator
 import (
 	"errors"
 	"strconv"
-	"strings"
 )
 
 var (
@@ -12,74 +11,125 @@ var (
 	ErrValidationIntIn  = errors.New("validation error, value dosen't match a subset of int")
 )
 
-func validateIntField(tag string, value []int, fieldName string) error {
-	var verr ValidationErrors
-	var err error
-	ve := []ValidationError{}
+// intRule compiles c for a signed integer kind of the given bit size.
+// Operands that don't fit the kind are rejected instead of being truncated.
+func intRule(c Condition, bits int) (rule, error) {
+	switch c.operator {
+	case "min":
+		m, err := strconv.ParseInt(c.operand, 10, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateIntMin(fl.Value.Int(), m) }}, nil
+	case "max":
+		m, err := strconv.ParseInt(c.operand, 10, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateIntMax(fl.Value.Int(), m) }}, nil
+	case "in":
+		in, err := parseIntIn(c.params, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateIntIn(fl.Value.Int(), in) }}, nil
+	default:
+		return rule{}, ErrUnsupCondition
+	}
+}
 
-	cond, err := parseConditions(tag)
-	if err != nil {
-		return err
+// uintRule compiles c for an unsigned integer kind of the given bit size.
+func uintRule(c Condition, bits int) (rule, error) {
+	switch c.operator {
+	case "min":
+		m, err := strconv.ParseUint(c.operand, 10, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateUintMin(fl.Value.Uint(), m) }}, nil
+	case "max":
+		m, err := strconv.ParseUint(c.operand, 10, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateUintMax(fl.Value.Uint(), m) }}, nil
+	case "in":
+		in, err := parseUintIn(c.params, bits)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateUintIn(fl.Value.Uint(), in) }}, nil
+	default:
+		return rule{}, ErrUnsupCondition
 	}
+}
 
-	for _, v := range value {
-		for _, c := range cond {
-			switch c.operator {
-			case "min":
-				err = validateIntMin(v, c.operand, fieldName)
-			case "max":
-				err = validateIntMax(v, c.operand, fieldName)
-			case "in":
-				err = validateIntIn(v, c.operand, fieldName)
-			default:
-				return ErrUnsupCondition
-			}
-			if err != nil {
-				if !errors.As(err, &verr) {
-					return err
-				}
-				ve = append(ve, verr...)
-			}
+func parseIntIn(in []string, bits int) ([]int64, error) {
+	subset := []int64{}
+	for _, is := range in {
+		i, err := strconv.ParseInt(is, 10, bits)
+		if err != nil {
+			return nil, err
 		}
+		subset = append(subset, i)
 	}
-	if len(ve) > 0 {
-		return ValidationErrors(ve)
+	return subset, nil
+}
+
+func parseUintIn(in []string, bits int) ([]uint64, error) {
+	subset := []uint64{}
+	for _, is := range in {
+		i, err := strconv.ParseUint(is, 10, bits)
+		if err != nil {
+			return nil, err
+		}
+		subset = append(subset, i)
 	}
-	return err
+	return subset, nil
 }
 
-func validateIntMin(field int, min string, name string) error {
-	m, err := strconv.Atoi(min)
-	if err != nil {
-		return err
+func validateIntMin(field int64, min int64) error {
+	if field < min {
+		return ErrValidationIntMin
 	}
-	if field < m {
-		return ValidationErrors{ValidationError{Field: name, Err: ErrValidationIntMin}}
+	return nil
+}
+
+func validateIntMax(field int64, max int64) error {
+	if field > max {
+		return ErrValidationIntMax
 	}
 	return nil
 }
 
-func validateIntMax(field int, max string, name string) error {
-	m, err := strconv.Atoi(max)
-	if err != nil {
-		return err
+func validateIntIn(field int64, in []int64) error {
+	for _, i := range in {
+		if field == i {
+			return nil
+		}
 	}
-	if field > m {
-		return ValidationErrors{ValidationError{Field: name, Err: ErrValidationIntMax}}
+	return ErrValidationIntIn
+}
+
+func validateUintMin(field uint64, min uint64) error {
+	if field < min {
+		return ErrValidationIntMin
 	}
 	return nil
 }
 
-func validateIntIn(field int, in string, name string) error {
-	for _, is := range strings.Split(in, inSplitSymbol) {
-		if _, err := strconv.Atoi(is); err != nil {
-			return err
-		}
+func validateUintMax(field uint64, max uint64) error {
+	if field > max {
+		return ErrValidationIntMax
+	}
+	return nil
+}
 
-		if strconv.Itoa(field) == is {
+func validateUintIn(field uint64, in []uint64) error {
+	for _, i := range in {
+		if field == i {
 			return nil
 		}
 	}
-
-	return ValidationErrors{ValidationError{Field: name, Err: ErrValidationIntIn}}
+	return ErrValidationIntIn
 }
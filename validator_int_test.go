@@ -28,7 +28,7 @@ func TestValidateIntField(t *testing.T) {
 			name:  "validate err in",
 			input: in{tag: "in:0,11", value: []int{12}, name: "in"},
 			err: ValidationErrors{
-				ValidationError{Field: "in", Err: ErrValidationIntIn},
+				fieldError(ErrValidationIntIn, "in", 0),
 			},
 		},
 		{
@@ -40,7 +40,7 @@ func TestValidateIntField(t *testing.T) {
 			name:  "validate err max",
 			input: in{tag: "max:50", value: []int{60}, name: "max"},
 			err: ValidationErrors{
-				ValidationError{Field: "max", Err: ErrValidationIntMax},
+				fieldError(ErrValidationIntMax, "max", 0),
 			},
 		},
 		{
@@ -52,7 +52,7 @@ func TestValidateIntField(t *testing.T) {
 			name:  "validate err min",
 			input: in{tag: "min:60", value: []int{50}, name: "min"},
 			err: ValidationErrors{
-				ValidationError{Field: "min", Err: ErrValidationIntMin},
+				fieldError(ErrValidationIntMin, "min", 0),
 			},
 		},
 		{
@@ -64,9 +64,9 @@ func TestValidateIntField(t *testing.T) {
 			name:  "validate err multi conditions",
 			input: in{tag: "in:0,11|max:6|min:13", value: []int{12}, name: "multi"},
 			err: ValidationErrors{
-				ValidationError{Field: "multi", Err: ErrValidationIntIn},
-				ValidationError{Field: "multi", Err: ErrValidationIntMax},
-				ValidationError{Field: "multi", Err: ErrValidationIntMin},
+				fieldError(ErrValidationIntIn, "multi", 0),
+				fieldError(ErrValidationIntMax, "multi", 0),
+				fieldError(ErrValidationIntMin, "multi", 0),
 			},
 		},
 		{
@@ -78,11 +78,11 @@ func TestValidateIntField(t *testing.T) {
 			name:  "validate slice err multi conditions",
 			input: in{tag: "in:0,11|max:6|min:13", value: []int{12, 5}, name: "multi"},
 			err: ValidationErrors{
-				ValidationError{Field: "multi", Err: ErrValidationIntIn},
-				ValidationError{Field: "multi", Err: ErrValidationIntMax},
-				ValidationError{Field: "multi", Err: ErrValidationIntMin},
-				ValidationError{Field: "multi", Err: ErrValidationIntIn},
-				ValidationError{Field: "multi", Err: ErrValidationIntMin},
+				fieldError(ErrValidationIntIn, "multi", 0),
+				fieldError(ErrValidationIntMax, "multi", 0),
+				fieldError(ErrValidationIntMin, "multi", 0),
+				fieldError(ErrValidationIntIn, "multi", 1),
+				fieldError(ErrValidationIntMin, "multi", 1),
 			},
 		},
 		{
@@ -101,7 +101,7 @@ func TestValidateIntField(t *testing.T) {
 	var serr *strconv.NumError
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateIntField(tc.input.tag, tc.input.value, tc.input.name)
+			err := validateTagged(tc.input.name, tc.input.tag, tc.input.value)
 			if errors.As(err, &verr) {
 				require.Equal(t, tc.err, verr)
 				return
@@ -128,15 +128,10 @@ func TestValidateIntIn(t *testing.T) {
 		{name: "in validate err", v: 40, in: "50,60", err: ErrValidationIntIn},
 	}
 
-	var verr ValidationErrors
 	var serr *strconv.NumError
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateIntIn(tc.v, tc.in, tc.name)
-			if errors.As(err, &verr) {
-				require.Equal(t, tc.err, verr[0].Err)
-				return
-			}
+			err := checkRule("in:"+tc.in, tc.v)
 			if errors.As(err, &serr) {
 				require.Equal(t, tc.err, serr.Err)
 				return
@@ -159,15 +154,10 @@ func TestValidateIntMax(t *testing.T) {
 		{name: "greater", v: 60, max: "50", err: ErrValidationIntMax},
 	}
 
-	var verr ValidationErrors
 	var serr *strconv.NumError
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateIntMax(tc.v, tc.max, tc.name)
-			if errors.As(err, &verr) {
-				require.Equal(t, tc.err, verr[0].Err)
-				return
-			}
+			err := checkRule("max:"+tc.max, tc.v)
 			if errors.As(err, &serr) {
 				require.Equal(t, tc.err, serr.Err)
 				return
@@ -190,15 +180,46 @@ func TestValidateIntMin(t *testing.T) {
 		{name: "greater", v: 2, min: "1", err: nil},
 	}
 
-	var verr ValidationErrors
 	var serr *strconv.NumError
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateIntMin(tc.v, tc.min, tc.name)
-			if errors.As(err, &verr) {
-				require.Equal(t, tc.err, verr[0].Err)
+			err := checkRule("min:"+tc.min, tc.v)
+			if errors.As(err, &serr) {
+				require.Equal(t, tc.err, serr.Err)
 				return
 			}
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func TestValidateIntKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		v    interface{}
+		err  error
+	}{
+		{name: "int8", tag: "max:127", v: int8(127), err: nil},
+		{name: "int8 err", tag: "min:-5", v: int8(-6), err: ErrValidationIntMin},
+		{name: "int8 overflow", tag: "max:128", v: int8(0), err: strconv.ErrRange},
+		{name: "int16 in", tag: "in:-300,300", v: int16(-300), err: nil},
+		{name: "int32 err", tag: "max:100", v: int32(101), err: ErrValidationIntMax},
+		{name: "int64", tag: "min:9223372036854775807", v: int64(9223372036854775807), err: nil},
+		{name: "uint8", tag: "in:0,255", v: uint8(255), err: nil},
+		{name: "uint8 overflow", tag: "in:0,256", v: uint8(0), err: strconv.ErrRange},
+		{name: "uint16 err", tag: "min:1024", v: uint16(80), err: ErrValidationIntMin},
+		{name: "uint negative", tag: "min:-1", v: uint(0), err: strconv.ErrSyntax},
+		{name: "uint64", tag: "max:18446744073709551615", v: uint64(18446744073709551615), err: nil},
+		{name: "uint64 err", tag: "max:18446744073709551614", v: uint64(18446744073709551615), err: ErrValidationIntMax},
+		{name: "uintptr", tag: "min:1", v: uintptr(1), err: nil},
+		{name: "unsupported condition", tag: "len:1", v: uint(1), err: ErrUnsupCondition},
+	}
+
+	var serr *strconv.NumError
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkRule(tc.tag, tc.v)
 			if errors.As(err, &serr) {
 				require.Equal(t, tc.err, serr.Err)
 				return
@@ -0,0 +1,160 @@
+package struct_validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type PaymentMethod interface {
+	Kind() string
+}
+
+type Card struct {
+	Number string `validate:"len:16"`
+}
+
+func (Card) Kind() string { return "card" }
+
+type Wallet struct {
+	Email string `validate:"email"`
+}
+
+func (*Wallet) Kind() string { return "wallet" }
+
+func TestValidateInterfaceField(t *testing.T) {
+	type Order struct {
+		Payment  PaymentMethod          `validate:"required|nested"`
+		Backup   PaymentMethod          `validate:"nested"`
+		Note     interface{}            `validate:"omitempty|maxlen:5"`
+		Amount   any                    `validate:"min:1"`
+		Items    []interface{}          `validate:"minlen:1|dive|required|nested"`
+		Metadata map[string]interface{} `validate:"dive|keys|lowercase|endkeys|maxlen:3"`
+	}
+
+	tests := []struct {
+		name        string
+		in          Order
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			in: Order{
+				Payment:  Card{Number: "4242424242424242"},
+				Backup:   &Wallet{Email: "bob@example.com"},
+				Note:     "gift",
+				Amount:   uint8(5),
+				Items:    []interface{}{Card{Number: "4242424242424242"}, &Wallet{Email: "a@b.c"}},
+				Metadata: map[string]interface{}{"tag": "abc", "ids": "1,2"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid dynamic values",
+			in: Order{
+				Payment:  &Card{Number: "4242"},
+				Backup:   &Wallet{Email: "bob"},
+				Note:     "too long",
+				Amount:   -1.5,
+				Items:    []interface{}{Card{Number: "1"}, nil, (*Wallet)(nil)},
+				Metadata: map[string]interface{}{"Tag": "abcd"},
+			},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationStrLen, "Payment", "Number"),
+				fieldError(ErrValidationEmail, "Backup", "Email"),
+				fieldError(ErrValidationStrMaxLen, "Note"),
+				fieldError(ErrValidationFloatMin, "Amount"),
+				fieldError(ErrValidationStrLen, "Items", 0, "Number"),
+				fieldError(ErrRequired, "Items", 1),
+				fieldError(ErrRequired, "Items", 2),
+				fieldError(ErrValidationStrLowercase, "Metadata", PathElem{Key: "Tag"}),
+				fieldError(ErrValidationStrMaxLen, "Metadata", PathElem{Key: "Tag"}),
+			},
+		},
+		{
+			name: "nil interfaces",
+			in:   Order{Backup: (*Wallet)(nil), Amount: 1},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "Payment"),
+				fieldError(ErrValidationMinLen, "Items"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
+		})
+	}
+}
+
+func TestValidateInterfaceFieldErrors(t *testing.T) {
+	type Value struct {
+		V interface{} `validate:"min:1"`
+	}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		errs  []error
+	}{
+		{name: "nil", value: nil, errs: nil},
+		{name: "fit", value: 1, errs: nil},
+		{name: "unsupported condition", value: "one", errs: []error{ErrUnsupType, ErrUnsupCondition}},
+		{name: "unsupported type", value: complex(1, 1), errs: []error{ErrUnsupType}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ { // the second time from the cache
+				err := Validate(Value{V: tc.value})
+				if tc.errs == nil {
+					require.NoError(t, err)
+					continue
+				}
+				var ve ValidationErrors
+				require.ErrorAs(t, err, &ve)
+				require.Len(t, ve, 1)
+				require.Equal(t, "V", ve[0].Field)
+				for _, target := range tc.errs {
+					require.ErrorIs(t, ve[0].Err, target)
+				}
+			}
+		})
+	}
+
+	type Values struct {
+		A int         `validate:"min:1"`
+		V interface{} `validate:"min:1"`
+		B int         `validate:"min:1"`
+	}
+	err := Validate(Values{V: "one"})
+	var ve ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	require.Len(t, ve, 3)
+	require.Equal(t, []string{"A", "V", "B"}, []string{ve[0].Field, ve[1].Field, ve[2].Field})
+	require.ErrorIs(t, ve[1].Err, ErrUnsupType)
+}
+
+func TestValidateInterfaceCustom(t *testing.T) {
+	type Value struct {
+		V interface{} `validate:"custom"`
+	}
+
+	v := New()
+	var ve ValidationErrors
+	require.ErrorAs(t, v.Validate(Value{V: 1}), &ve)
+	require.Len(t, ve, 1)
+	require.Equal(t, "V", ve[0].Field)
+	require.ErrorIs(t, ve[0].Err, ErrUnsupType)
+	require.ErrorIs(t, ve[0].Err, ErrUnsupCondition)
+	require.NoError(t, v.RegisterValidation("custom", func(fl FieldLevel) error {
+		if fl.Value.Kind() != reflect.Int {
+			return errSKU
+		}
+		return nil
+	}))
+	require.NoError(t, v.Validate(Value{V: 1}))
+	require.Equal(t, ValidationErrors{fieldError(errSKU, "V")}, v.Validate(Value{V: "1"}))
+}
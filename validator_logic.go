@@ -0,0 +1,113 @@
+package struct_validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrValidationAlternatives = errors.New("validation error, none of the alternatives is satisfied")
+	ErrValidationNegated      = errors.New("validation error, negated condition is satisfied")
+)
+
+// AlternativesError is a failed group of alternatives. It holds the failures
+// of every alternative in tag order and matches ErrValidationAlternatives as
+// well as any of them.
+type AlternativesError struct {
+	Alternatives [][]error
+}
+
+func (e *AlternativesError) Error() string {
+	alts := make([]string, len(e.Alternatives))
+	for i, errs := range e.Alternatives {
+		msgs := make([]string, len(errs))
+		for j, err := range errs {
+			msgs[j] = err.Error()
+		}
+		alts[i] = "(" + strings.Join(msgs, "; ") + ")"
+	}
+	return ErrValidationAlternatives.Error() + ": " + strings.Join(alts, " or ")
+}
+
+func (e *AlternativesError) Is(target error) bool {
+	return target == ErrValidationAlternatives //nolint:errorlint
+}
+
+func (e *AlternativesError) Unwrap() []error {
+	var errs []error
+	for _, alt := range e.Alternatives {
+		errs = append(errs, alt...)
+	}
+	return errs
+}
+
+// NegationError is a satisfied negated condition, it matches
+// ErrValidationNegated but none of the errors of the condition itself.
+type NegationError struct {
+	Condition string // without the negation, e.g. "in:root,admin"
+}
+
+func (e *NegationError) Error() string {
+	return ErrValidationNegated.Error() + ": " + e.Condition
+}
+
+func (e *NegationError) Is(target error) bool {
+	return target == ErrValidationNegated //nolint:errorlint
+}
+
+// negatedRule compiles c, which must be negated, to a rule that fails when
+// the condition is satisfied.
+func (v *Validator) negatedRule(c Condition, parent, t reflect.Type) (rule, error) {
+	inner := c
+	inner.negated = false
+	r, err := v.compileRule(inner, parent, t)
+	if err != nil {
+		return rule{}, err
+	}
+	if r.check == nil {
+		return rule{}, ErrUnsupCondition // "nested" can't be negated
+	}
+	cond := inner.format(v.syntax)
+	return rule{c, func(fl FieldLevel) error {
+		if r.check(fl) == nil {
+			return &NegationError{Condition: cond}
+		}
+		return nil
+	}}, nil
+}
+
+func (v *Validator) anyOfRule(c Condition, parent, t reflect.Type) (rule, error) {
+	alts := make([][]rule, len(c.anyOf))
+	for i, chain := range c.anyOf {
+		for _, ac := range chain {
+			r, err := v.compileRule(ac, parent, t)
+			if err != nil {
+				return rule{}, err
+			}
+			if r.check == nil {
+				return rule{}, ErrUnsupCondition // "nested" can't be an alternative
+			}
+			alts[i] = append(alts[i], r)
+		}
+	}
+	return rule{c, func(fl FieldLevel) error { return validateAnyOf(fl, alts) }}, nil
+}
+
+func validateAnyOf(fl FieldLevel, alts [][]rule) error {
+	var failed [][]error
+	for _, alt := range alts {
+		var errs []error
+		for _, r := range alt {
+			fl.Operand = r.operand
+			if err := r.check(fl); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) == 0 {
+			return nil
+		}
+		failed = append(failed, errs)
+	}
+	return &AlternativesError{Alternatives: failed}
+}
@@ -0,0 +1,204 @@
+package struct_validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAnyOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   string
+		value interface{}
+		err   error
+	}{
+		{name: "first alternative", tag: "len:0||len:36", value: "", err: nil},
+		{name: "second alternative", tag: "len:0||len:36", value: "123e4567-e89b-12d3-a456-426614174000", err: nil},
+		{name: "no alternative", tag: "len:0||len:36", value: "abc", err: ErrValidationStrLen},
+		{name: "mixed operators", tag: "in:a,b||regexp:^x", value: "xyz", err: nil},
+		{name: "mixed operators failed", tag: "in:a,b||regexp:^x", value: "c", err: ErrValidationStrRegexp},
+		{name: "int outside a range", tag: "max:10||min:100", value: 101, err: nil},
+		{name: "int inside a range", tag: "max:10||min:100", value: 50, err: ErrValidationIntMin},
+		{name: "chain in alternative", tag: "min:1|max:5||min:10|max:15", value: 12, err: nil},
+		{name: "chain in alternative failed", tag: "min:1|max:5||min:10|max:15", value: 7, err: ErrValidationIntMax},
+		{name: "group", tag: "len:3|(in:foo||in:bar)", value: "bar", err: nil},
+		{name: "group failed", tag: "len:3|(in:foo||in:bar)", value: "baz", err: ErrValidationStrIn},
+		{name: "condition before a group failed", tag: "len:3|(in:foo||in:fooo)", value: "fooo", err: ErrValidationStrLen},
+		{name: "nested groups", tag: "(len:1||(len:3|(in:foo||in:bar)))", value: "foo", err: nil},
+		{name: "float", tag: "max:0.5||min:1.5", value: 1.0, err: ErrValidationFloatMax},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTagged("Field", tc.tag, tc.value)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			var ve ValidationErrors
+			require.ErrorAs(t, err, &ve)
+			require.Len(t, ve, 1)
+			require.ErrorIs(t, ve[0].Err, tc.err)
+		})
+	}
+}
+
+func TestAlternativesError(t *testing.T) {
+	err := checkRule("len:0||len:2|in:ab", "abc")
+
+	var aerr *AlternativesError
+	require.ErrorAs(t, err, &aerr)
+	require.ErrorIs(t, err, ErrValidationAlternatives)
+	require.ErrorIs(t, err, ErrValidationStrLen)
+	require.ErrorIs(t, err, ErrValidationStrIn)
+	require.Equal(t, [][]error{{ErrValidationStrLen}, {ErrValidationStrLen, ErrValidationStrIn}}, aerr.Alternatives)
+	require.Equal(t, ErrValidationAlternatives.Error()+": ("+ErrValidationStrLen.Error()+") or ("+
+		ErrValidationStrLen.Error()+"; "+ErrValidationStrIn.Error()+")", err.Error())
+}
+
+func TestValidateAnyOfStruct(t *testing.T) {
+	type Inner struct {
+		Name string `validate:"len:3"`
+	}
+	type Contact struct {
+		Inner Inner `validate:"nested||len:1"`
+	}
+
+	v := New()
+	require.ErrorIs(t, v.Validate(Contact{}), ErrUnsupCondition)
+
+	type Account struct {
+		Email string   `validate:"len:0||regexp:^\\w+@\\w+\\.\\w+$"`
+		Tags  []string `validate:"in:a,b||len:5"`
+	}
+	err := v.Validate(Account{Email: "foo", Tags: []string{"a", "abcde", "c"}})
+	var ve ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	require.Len(t, ve, 2)
+	require.Equal(t, "Email", ve[0].Field)
+	require.ErrorIs(t, ve[0].Err, ErrValidationAlternatives)
+	require.Equal(t, "Tags[2]", ve[1].Field)
+	require.ErrorIs(t, ve[1].Err, ErrValidationStrIn)
+}
+
+func TestValidateAnyOfCustom(t *testing.T) {
+	errEven := errors.New("validation error, odd")
+	v := New(WithOrSeparator(" or "))
+	require.NoError(t, v.RegisterValidation("even", func(fl FieldLevel) error {
+		if fl.Value.Int()%2 != 0 {
+			return errEven
+		}
+		return nil
+	}))
+
+	type Counter struct {
+		N int `validate:"even or max:3"`
+	}
+	require.NoError(t, v.Validate(Counter{N: 3}))
+	require.NoError(t, v.Validate(Counter{N: 8}))
+
+	var ve ValidationErrors
+	require.ErrorAs(t, v.Validate(Counter{N: 9}), &ve)
+	require.Len(t, ve, 1)
+	require.ErrorIs(t, ve[0].Err, errEven)
+	require.ErrorIs(t, ve[0].Err, ErrValidationIntMax)
+}
+
+func TestValidateNegated(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   string
+		value interface{}
+		err   error
+	}{
+		{name: "not in", tag: "!in:root,admin", value: "alice", err: nil},
+		{name: "not in failed", tag: "!in:root,admin", value: "root", err: ErrValidationNegated},
+		{name: "not regexp", tag: `!regexp:^\d+$`, value: "12a", err: nil},
+		{name: "not regexp failed", tag: `!regexp:^\d+$`, value: "123", err: ErrValidationNegated},
+		{name: "int not in", tag: "!in:0,13", value: 7, err: nil},
+		{name: "int not in failed", tag: "!in:0,13", value: 13, err: ErrValidationNegated},
+		{name: "uint not max", tag: "!max:10", value: uint8(5), err: ErrValidationNegated},
+		{name: "float not min", tag: "!min:1.5", value: 1.0, err: nil},
+		{name: "with a plain condition", tag: "len:5|!in:admin", value: "admin", err: ErrValidationNegated},
+		{name: "plain condition failed", tag: "len:5|!in:admin", value: "root", err: ErrValidationStrLen},
+		{name: "negated group", tag: "!(len:4|in:root,test)", value: "root", err: ErrValidationNegated},
+		{name: "negated group partly satisfied", tag: "!(len:4|in:root,test)", value: "alice", err: nil},
+		{name: "in alternatives", tag: "!in:a,bc||len:1", value: "a", err: nil},
+		{name: "in alternatives failed", tag: "!in:a,bc||len:1", value: "bc", err: ErrValidationAlternatives},
+		{name: "slice elements", tag: "!in:root", value: []string{"alice", "root"}, err: ErrValidationNegated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTagged("Field", tc.tag, tc.value)
+			if tc.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			var ve ValidationErrors
+			require.ErrorAs(t, err, &ve)
+			require.Len(t, ve, 1)
+			require.ErrorIs(t, ve[0].Err, tc.err)
+		})
+	}
+}
+
+func TestNegationError(t *testing.T) {
+	err := checkRule("!in:root,admin", "admin")
+
+	var nerr *NegationError
+	require.ErrorAs(t, err, &nerr)
+	require.Equal(t, "in:root,admin", nerr.Condition)
+	require.ErrorIs(t, err, ErrValidationNegated)
+	require.NotErrorIs(t, err, ErrValidationStrIn)
+	require.Equal(t, ErrValidationNegated.Error()+": in:root,admin", err.Error())
+
+	// A plain failure doesn't match the negated one.
+	require.NotErrorIs(t, checkRule("in:root,admin", "alice"), ErrValidationNegated)
+}
+
+func TestValidateNegatedCustom(t *testing.T) {
+	errReserved := errors.New("validation error, reserved name")
+	v := New()
+	require.NoError(t, v.RegisterValidation("reserved", func(fl FieldLevel) error {
+		if fl.Value.String() == "root" {
+			return nil
+		}
+		return errReserved
+	}))
+
+	type User struct {
+		Name string `validate:"!reserved"`
+	}
+	require.NoError(t, v.Validate(User{Name: "alice"}))
+
+	var ve ValidationErrors
+	require.ErrorAs(t, v.Validate(User{Name: "root"}), &ve)
+	require.Len(t, ve, 1)
+	require.ErrorIs(t, ve[0].Err, ErrValidationNegated)
+	require.NotErrorIs(t, ve[0].Err, errReserved)
+}
+
+func TestValidateNegatedStructural(t *testing.T) {
+	type Inner struct {
+		Name string `validate:"len:3"`
+	}
+	type NotRequired struct {
+		Name *string `validate:"!required"`
+	}
+	type NotNested struct {
+		Inner Inner `validate:"!nested"`
+	}
+	type NotOmitempty struct {
+		Name string `validate:"!omitempty"`
+	}
+	type NotDive struct {
+		Names []string `validate:"!dive|len:3"`
+	}
+
+	for _, s := range []interface{}{NotRequired{}, NotOmitempty{}, NotNested{}, NotDive{}} {
+		require.ErrorIs(t, Validate(s), ErrUnsupCondition, "%T", s)
+	}
+}
@@ -0,0 +1,66 @@
+package struct_validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// splitMapDive splits the tag of a map into the conditions of the map itself,
+// of its keys and of its values:
+//
+//	minlen:1|dive|keys|len:3|endkeys|min:0
+func splitMapDive(cond []Condition) (m, key, elem []Condition, err error) {
+	m, elem, _ = cutDive(cond)
+	if len(elem) == 0 || elem[0].operator != "keys" || elem[0].negated {
+		return m, nil, elem, nil
+	}
+	for i, c := range elem {
+		if c.operator == "endkeys" && !c.negated {
+			return m, elem[1:i], elem[i+1:], nil
+		}
+	}
+	return nil, nil, nil, fmt.Errorf("%w: keys without endkeys", ErrValidationFormat)
+}
+
+// validateMap validates the keys and values of the map v, found at path,
+// against p. They are walked in the order of the keys, so that errors are
+// reported in the same order every time.
+func (w *walker) validateMap(v reflect.Value, p *valuePlan, path Path, parent reflect.Value) error {
+	if p.key == nil && p.elem == nil {
+		return nil
+	}
+	for _, k := range sortedKeys(v) {
+		elemPath := append(path, PathElem{Key: k.Interface()})
+		if p.key != nil {
+			if err := w.validateValue(k, p.key, elemPath, parent); err != nil {
+				return err
+			}
+		}
+		if p.elem != nil {
+			if err := w.validateValue(v.MapIndex(k), p.elem, elemPath, parent); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	var less func(a, b reflect.Value) bool
+	switch v.Type().Key().Kind() { //nolint:exhaustive
+	case reflect.String:
+		less = func(a, b reflect.Value) bool { return a.String() < b.String() }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	default:
+		less = func(a, b reflect.Value) bool { return fmt.Sprint(a) < fmt.Sprint(b) }
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	return keys
+}
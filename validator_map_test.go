@@ -0,0 +1,118 @@
+package struct_validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMapField(t *testing.T) {
+	type (
+		Limit struct {
+			Max int `validate:"min:1"`
+		}
+
+		Config struct {
+			Quotas  map[string]int            `validate:"minlen:1|dive|keys|in:cpu,mem,disk|endkeys|min:0|max:100"`
+			Labels  map[string]string         `validate:"maxlen:2|dive|keys|lowercase|endkeys|omitempty|len:3"`
+			Limits  map[int]*Limit            `validate:"dive|keys|min:1|endkeys|required|nested"`
+			Ports   map[string]uint16         `validate:"dive|min:1024"`
+			Zones   map[string][]string       `validate:"dive|minlen:1|dive|hostname"`
+			Owners  map[string]string         `validate:"dive|keys|email|endkeys"`
+			Options map[string]interface{}    `validate:"maxlen:1"`
+			Tags    map[string]map[string]int `validate:"dive|dive|keys|len:1|endkeys"`
+		}
+	)
+
+	tests := []struct {
+		name        string
+		in          Config
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			in: Config{
+				Quotas: map[string]int{"cpu": 4, "mem": 64},
+				Labels: map[string]string{"env": "dev", "team": ""},
+				Limits: map[int]*Limit{1: {Max: 1}},
+				Ports:  map[string]uint16{"http": 8080},
+				Zones:  map[string][]string{"eu": {"eu-1.example.com"}},
+				Owners: map[string]string{"bob@example.com": "Bob"},
+				Tags:   map[string]map[string]int{"a": {"b": 1}},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid",
+			in: Config{
+				Quotas:  map[string]int{"mem": 64, "gpu": 1, "cpu": 400},
+				Labels:  map[string]string{"Env": "dev", "team": "core", "app": "api"},
+				Limits:  map[int]*Limit{2: nil, 0: {Max: 1}, 1: {Max: 0}},
+				Ports:   map[string]uint16{"http": 80},
+				Zones:   map[string][]string{"us": {}, "eu": {"eu_1"}},
+				Owners:  map[string]string{"bob": "Bob"},
+				Options: map[string]interface{}{"a": 1, "b": 2},
+				Tags:    map[string]map[string]int{"x": {"yy": 1}},
+			},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationIntMax, "Quotas", PathElem{Key: "cpu"}),
+				fieldError(ErrValidationStrIn, "Quotas", PathElem{Key: "gpu"}),
+				fieldError(ErrValidationMaxLen, "Labels"),
+				fieldError(ErrValidationStrLowercase, "Labels", PathElem{Key: "Env"}),
+				fieldError(ErrValidationStrLen, "Labels", PathElem{Key: "team"}),
+				fieldError(ErrValidationIntMin, "Limits", PathElem{Key: 0}),
+				fieldError(ErrValidationIntMin, "Limits", PathElem{Key: 1}, "Max"),
+				fieldError(ErrRequired, "Limits", PathElem{Key: 2}),
+				fieldError(ErrValidationIntMin, "Ports", PathElem{Key: "http"}),
+				fieldError(ErrValidationHostname, "Zones", PathElem{Key: "eu"}, 0),
+				fieldError(ErrValidationMinLen, "Zones", PathElem{Key: "us"}),
+				fieldError(ErrValidationEmail, "Owners", PathElem{Key: "bob"}),
+				fieldError(ErrValidationMaxLen, "Options"),
+				fieldError(ErrValidationStrLen, "Tags", PathElem{Key: "x"}, PathElem{Key: "yy"}),
+			},
+		},
+		{
+			name:        "nil maps",
+			in:          Config{},
+			expectedErr: ValidationErrors{fieldError(ErrValidationMinLen, "Quotas")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
+		})
+	}
+}
+
+func TestValidateMapFieldPath(t *testing.T) {
+	type Config struct {
+		Quotas map[string]int `validate:"dive|max:10"`
+	}
+
+	var ve ValidationErrors
+	require.ErrorAs(t, Validate(Config{Quotas: map[string]int{"cpu": 11}}), &ve)
+	require.Equal(t, "Quotas[cpu]", ve[0].Field)
+}
+
+func TestValidateMapFieldErrors(t *testing.T) {
+	type (
+		NoEndKeys struct {
+			Quotas map[string]int `validate:"dive|keys|len:3"`
+		}
+		KeysOnSlice struct {
+			Quotas []string `validate:"dive|keys|len:3|endkeys"`
+		}
+		Nested struct {
+			Quotas map[string]int `validate:"nested"`
+		}
+		ValueRule struct {
+			Quotas map[string]int `validate:"min:1"`
+		}
+	)
+
+	require.ErrorIs(t, Validate(NoEndKeys{}), ErrValidationFormat)
+	require.ErrorIs(t, Validate(KeysOnSlice{}), ErrUnsupCondition)
+	require.ErrorIs(t, Validate(Nested{}), ErrUnsupCondition)
+	require.ErrorIs(t, Validate(ValueRule{}), ErrUnsupCondition)
+}
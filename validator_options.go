@@ -0,0 +1,105 @@
+package struct_validator
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Option func(*Validator)
+
+// WithFieldNameTag reports fields in error paths by their name in the given
+// struct tag, e.g. "json". A "-" or empty name falls back to the Go field name,
+// options after the first comma (",omitempty") are ignored.
+func WithFieldNameTag(tag string) Option {
+	return WithFieldNameFunc(func(sf reflect.StructField) string {
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+}
+
+// WithFieldNameFunc reports fields in error paths by the name fn returns for
+// them. An empty name falls back to the Go field name.
+func WithFieldNameFunc(fn func(sf reflect.StructField) string) Option {
+	return func(v *Validator) {
+		v.fieldName = fn
+	}
+}
+
+// WithTagName reads conditions from the given struct tag instead of
+// "validate". An empty name is ignored.
+func WithTagName(name string) Option {
+	return func(v *Validator) {
+		if name != "" {
+			v.tagName = name
+		}
+	}
+}
+
+// WithOperandSeparator separates an operator from its operand, ":" by default.
+// An empty separator is ignored.
+func WithOperandSeparator(sep string) Option {
+	return func(v *Validator) {
+		if sep != "" {
+			v.syntax.operand = sep
+		}
+	}
+}
+
+// WithListSeparator separates the values of a list operand, "," by default.
+// An empty separator is ignored.
+func WithListSeparator(sep string) Option {
+	return func(v *Validator) {
+		if sep != "" {
+			v.syntax.list = sep
+		}
+	}
+}
+
+// WithAndSeparator separates conditions, "|" by default. An empty separator
+// is ignored.
+func WithAndSeparator(sep string) Option {
+	return func(v *Validator) {
+		if sep != "" {
+			v.syntax.and = sep
+		}
+	}
+}
+
+// WithOrSeparator separates alternatives, "||" by default. An empty separator
+// is ignored.
+func WithOrSeparator(sep string) Option {
+	return func(v *Validator) {
+		if sep != "" {
+			v.syntax.or = sep
+		}
+	}
+}
+
+// WithFailFast stops validation at the first failed condition, so at most one
+// ValidationError is returned.
+func WithFailFast() Option {
+	return func(v *Validator) {
+		v.failFast = true
+	}
+}
+
+// WithByteLength measures strings in bytes instead of runes.
+func WithByteLength() Option {
+	return func(v *Validator) {
+		v.byteLen = true
+	}
+}
+
+// WithClock makes time conditions relative to now, e.g. "past", instead of
+// time.Now. A nil now is ignored.
+func WithClock(now func() time.Time) Option {
+	return func(v *Validator) {
+		if now != nil {
+			v.now = now
+		}
+	}
+}
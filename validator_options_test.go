@@ -0,0 +1,192 @@
+package struct_validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldNameOptions(t *testing.T) {
+	type (
+		Item struct {
+			SKU string `json:"sku" validate:"len:8"`
+		}
+		Order struct {
+			ID     int      `json:"id,omitempty" validate:"min:1"`
+			Note   string   `json:"-" validate:"len:0"`
+			Status string   `json:",omitempty" validate:"in:new,paid"`
+			Items  []Item   `json:"items" validate:"nested"`
+			Tags   []string `yaml:"labels" validate:"len:3"`
+		}
+	)
+
+	order := Order{
+		ID:     0,
+		Note:   "note",
+		Status: "lost",
+		Items:  []Item{{SKU: "ABCD1234"}, {SKU: "ABC"}},
+		Tags:   []string{"foo", "ba"},
+	}
+
+	tests := []struct {
+		name string
+		v    *Validator
+		err  error
+	}{
+		{
+			name: "go names",
+			v:    New(),
+			err: ValidationErrors{
+				fieldError(ErrValidationIntMin, "ID"),
+				fieldError(ErrValidationStrLen, "Note"),
+				fieldError(ErrValidationStrIn, "Status"),
+				fieldError(ErrValidationStrLen, "Items", 1, "SKU"),
+				fieldError(ErrValidationStrLen, "Tags", 1),
+			},
+		},
+		{
+			name: "json names",
+			v:    New(WithFieldNameTag("json")),
+			err: ValidationErrors{
+				fieldError(ErrValidationIntMin, "id"),
+				fieldError(ErrValidationStrLen, "Note"),
+				fieldError(ErrValidationStrIn, "Status"),
+				fieldError(ErrValidationStrLen, "items", 1, "sku"),
+				fieldError(ErrValidationStrLen, "Tags", 1),
+			},
+		},
+		{
+			name: "yaml names",
+			v:    New(WithFieldNameTag("yaml")),
+			err: ValidationErrors{
+				fieldError(ErrValidationIntMin, "ID"),
+				fieldError(ErrValidationStrLen, "Note"),
+				fieldError(ErrValidationStrIn, "Status"),
+				fieldError(ErrValidationStrLen, "Items", 1, "SKU"),
+				fieldError(ErrValidationStrLen, "labels", 1),
+			},
+		},
+		{
+			name: "custom func",
+			v: New(WithFieldNameFunc(func(sf reflect.StructField) string {
+				if sf.Name == "Note" {
+					return ""
+				}
+				return strings.ToLower(sf.Name)
+			})),
+			err: ValidationErrors{
+				fieldError(ErrValidationIntMin, "id"),
+				fieldError(ErrValidationStrLen, "Note"),
+				fieldError(ErrValidationStrIn, "status"),
+				fieldError(ErrValidationStrLen, "items", 1, "sku"),
+				fieldError(ErrValidationStrLen, "tags", 1),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, tc.v.Validate(order))
+		})
+	}
+}
+
+func TestSyntaxOptions(t *testing.T) {
+	type Account struct {
+		Name  string   `check:"len=4;in=root/user"`
+		Roles []string `check:"minlen=1;dive;in=read/write" validate:"len:100"`
+		Age   int      `validate:"min:18"`
+	}
+
+	v := New(
+		WithTagName("check"),
+		WithOperandSeparator("="),
+		WithListSeparator("/"),
+		WithAndSeparator(";"),
+	)
+	require.NoError(t, v.Validate(Account{Name: "root", Roles: []string{"read"}}))
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationStrLen, "Name"),
+		fieldError(ErrValidationStrIn, "Name"),
+		fieldError(ErrValidationMinLen, "Roles"),
+	}, v.Validate(Account{Name: "admin"}))
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationStrIn, "Roles", 1),
+	}, v.Validate(Account{Name: "user", Roles: []string{"write", "read/write"}}))
+
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationIntMin, "Age"),
+	}, New().Validate(Account{Name: "admin"}))
+
+	ignored := New(WithTagName(""), WithOperandSeparator(""), WithListSeparator(""), WithAndSeparator(""))
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationIntMin, "Age"),
+	}, ignored.Validate(Account{Name: "admin"}))
+}
+
+func TestSyntaxOptionsConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "or equals and", opts: []Option{WithOrSeparator("|")}},
+		{name: "and equals or", opts: []Option{WithAndSeparator("||")}},
+		{name: "or starts and", opts: []Option{WithAndSeparator("||;"), WithOrSeparator("||")}},
+		{name: "and equals list", opts: []Option{WithAndSeparator(",")}},
+		{name: "list starts operand", opts: []Option{WithOperandSeparator("::"), WithListSeparator(":")}},
+		{name: "negation", opts: []Option{WithAndSeparator("!")}},
+		{name: "group", opts: []Option{WithOrSeparator(")(")}},
+		{name: "quote", opts: []Option{WithListSeparator("'")}},
+		{name: "backslash", opts: []Option{WithOperandSeparator(`\`)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				require.ErrorIs(t, err, ErrSeparator)
+			}()
+			New(tc.opts...)
+		})
+	}
+
+	require.NotPanics(t, func() { New(WithAndSeparator("&"), WithOrSeparator("&&")) })
+	require.NotPanics(t, func() { New(WithAndSeparator(";"), WithOrSeparator("|")) })
+}
+
+func TestFailFast(t *testing.T) {
+	user := User{
+		ID:     "7f0e3265-ca96-4b33-8858-fef9",
+		Age:    1,
+		Email:  "somemailgmail.com",
+		Role:   "role",
+		Phones: []string{"12345678901234567890"},
+	}
+
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationStrLen, "ID"),
+	}, New(WithFailFast()).Validate(user))
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationStrLen, "User", "Phones", 0),
+	}, New(WithFailFast()).Validate(Nested{
+		User:     User{ID: "7f0e3265-ca96-4b33-8858-fef9696cc71b", Age: 20, Email: "a@b.c", Role: "admin", Phones: []string{"1"}},
+		Intfield: 100,
+	}))
+	require.Len(t, New().Validate(user), 5)
+}
+
+func TestByteLength(t *testing.T) {
+	type Name struct {
+		First string `validate:"len:4"`
+		Last  string `validate:"minlen:2|maxlen:8"`
+	}
+	name := Name{First: "Дима", Last: "Петров"}
+
+	require.NoError(t, New().Validate(name))
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationStrLen, "First"),
+		fieldError(ErrValidationStrMaxLen, "Last"),
+	}, New(WithByteLength()).Validate(name))
+}
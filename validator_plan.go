@@ -0,0 +1,459 @@
+package struct_validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// rule is a Condition compiled against the kind of the value it checks.
+// check returns the reason the value doesn't pass, e.g. one of the ErrValidation* errors.
+type rule struct {
+	Condition
+	check ValidationFunc
+}
+
+// valuePlan is a validate tag compiled for a value of one static type.
+type valuePlan struct {
+	nested     bool
+	required   bool          // an empty value is an error
+	omitempty  bool          // an empty value is skipped
+	requiredIf []requirement // any of them makes the value required, none skips it if empty
+	rules      []rule
+	elem       *valuePlan // applied to every element of a slice or value of a map
+	key        *valuePlan // applied to every key of a map
+	dynamic    *dynamicPlan
+	hook       *hook
+}
+
+// fieldPlan is the compiled validate tag of a single struct field.
+type fieldPlan struct {
+	index int
+	name  string
+	embed []int // index of the embedded struct holding the field, nil for own fields
+	via   Path  // embedded structs the field isn't promoted through
+	*valuePlan
+}
+
+// structPlan holds the compiled validation of every tagged field of a struct
+// type, including the fields of the structs it embeds.
+type structPlan struct {
+	fields []fieldPlan
+	hook   *hook
+}
+
+func (v *Validator) planOf(t reflect.Type) (*structPlan, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrType
+	}
+	if p, ok := v.plans.Load(t); ok {
+		return p.(*structPlan), nil
+	}
+
+	// Registering a validation drops the cache, so it must wait for the plans
+	// being compiled with the previous set of funcs.
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	p, err := v.newStructPlan(t)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := v.plans.LoadOrStore(t, p)
+	return actual.(*structPlan), nil
+}
+
+func (v *Validator) newStructPlan(t reflect.Type) (*structPlan, error) {
+	f := flattener{v: v, p: &structPlan{hook: hookOf(t)}, visible: map[string]bool{}, types: map[reflect.Type]bool{t: true}}
+	for _, sf := range reflect.VisibleFields(t) {
+		f.visible[fmt.Sprint(sf.Index)] = true
+	}
+	if err := f.addFields(t, nil, nil); err != nil {
+		return nil, err
+	}
+	return f.p, nil
+}
+
+// flattener adds the fields of a struct to its plan together with the fields
+// of the structs it embeds, as Go promotes them.
+type flattener struct {
+	v       *Validator
+	p       *structPlan
+	visible map[string]bool       // indexes of the fields promoted to the struct
+	types   map[reflect.Type]bool // structs being flattened, to stop on cycles
+}
+
+// addFields adds the fields of the struct type t, embedded at index embed.
+// Embedded structs and pointers to structs are flattened. The fields that
+// aren't promoted, being shadowed or ambiguous, are reported prefixed by via,
+// the path of the embedded struct. A field tagged "-" is skipped, which also
+// opts an embedded struct out of the flattening.
+func (f *flattener) addFields(t reflect.Type, embed []int, via Path) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup(f.v.tagName)
+		if tag == skipTag {
+			continue
+		}
+		index := append(embed[:len(embed):len(embed)], i)
+		var path Path
+		if !f.visible[fmt.Sprint(index)] {
+			path = via
+		}
+
+		et := indirectType(sf.Type)
+		embedded := sf.Anonymous && et.Kind() == reflect.Struct && !f.types[et]
+		if sf.IsExported() && (tagged || !embedded && hasHooks(sf.Type)) {
+			fp, err := f.newFieldPlan(t, sf, tag, tagged)
+			if err != nil {
+				return err
+			}
+			if embedded {
+				// Its fields are flattened and its hooks promoted to t.
+				fp.nested, fp.hook = false, nil
+			}
+			fp.index, fp.embed, fp.via = i, embed, path
+			f.p.fields = append(f.p.fields, fp)
+		}
+		if embedded {
+			f.types[et] = true
+			err := f.addFields(et, index, append(path[:len(path):len(path)], PathElem{Name: f.v.nameOf(sf)}))
+			delete(f.types, et)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newFieldPlan compiles the field sf of the struct type t, an untagged one is
+// only validated for its hooks.
+func (f *flattener) newFieldPlan(t reflect.Type, sf reflect.StructField, tag string, tagged bool) (fieldPlan, error) {
+	if tagged {
+		return f.v.newFieldPlan(t, f.v.nameOf(sf), sf.Type, tag)
+	}
+	vp, err := f.v.newValuePlan(t, sf.Type, nil)
+	return fieldPlan{name: f.v.nameOf(sf), valuePlan: vp}, err
+}
+
+// newFieldPlan compiles tag for the field name of type t, held by a struct of
+// type parent.
+func (v *Validator) newFieldPlan(parent reflect.Type, name string, t reflect.Type, tag string) (fieldPlan, error) {
+	fp := fieldPlan{name: name}
+	cond, err := parseConditions(tag, v.syntax)
+	if err != nil {
+		var terr *TagError
+		if errors.As(err, &terr) {
+			terr.Field = name
+		}
+		return fp, err
+	}
+	fp.valuePlan, err = v.newValuePlan(parent, t, cond)
+	return fp, err
+}
+
+// newValuePlan compiles cond for values of type t. Conditions of a slice or
+// an array before "dive" apply to the slice itself and the ones after it to
+// every element. A slice tag without "dive" keeps the presence conditions,
+// e.g. "required", on the slice and applies the rest to the elements. Maps are
+// the same, except that a tag without "dive" applies to the map only and the
+// conditions after "dive" may start with a "keys|...|endkeys" chain for the
+// keys. An interface is checked for presence only, the whole tag is compiled
+// again for the dynamic type of its value when it's validated, and a value of
+// a type unfit for the tag fails the validation then. Fields named by conditional
+// requirements are looked up in parent. The elements of a type with a hook
+// are validated even without conditions, to call it.
+func (v *Validator) newValuePlan(parent, t reflect.Type, cond []Condition) (*valuePlan, error) {
+	p := &valuePlan{}
+	t = indirectType(t)
+	if t.Kind() == reflect.Interface {
+		p.dynamic = &dynamicPlan{parent: parent, cond: cond}
+	} else {
+		p.hook = hookOf(t)
+	}
+
+	var key, elem []Condition
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Slice, reflect.Array:
+		cond, elem = splitDive(cond)
+	case reflect.Map:
+		var err error
+		if cond, key, elem, err = splitMapDive(cond); err != nil {
+			return nil, err
+		}
+	}
+	if len(key) != 0 {
+		var err error
+		if p.key, err = v.newValuePlan(parent, t.Key(), key); err != nil {
+			return nil, err
+		}
+	}
+	if len(elem) != 0 || hasElems(t) && hookOf(t.Elem()) != nil {
+		var err error
+		if p.elem, err = v.newValuePlan(parent, t.Elem(), elem); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range cond {
+		switch {
+		case presence[c.operator] && c.negated:
+			return nil, ErrUnsupCondition
+		case c.operator == "required":
+			p.required = true
+			continue
+		case c.operator == "omitempty":
+			p.omitempty = true
+			continue
+		case presence[c.operator]:
+			req, err := requirementOf(c, parent)
+			if err != nil {
+				return nil, err
+			}
+			p.requiredIf = append(p.requiredIf, req)
+			continue
+		case p.dynamic != nil:
+			continue
+		}
+		r, err := v.compileRule(c, parent, t)
+		if err != nil {
+			return nil, err
+		}
+		if r.operator == "nested" {
+			p.nested = true
+			continue
+		}
+		p.rules = append(p.rules, r)
+	}
+	return p, nil
+}
+
+func hasElems(t reflect.Type) bool {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+func splitDive(cond []Condition) (slice []Condition, elem []Condition) {
+	if slice, elem, ok := cutDive(cond); ok {
+		return slice, elem
+	}
+	for _, c := range cond {
+		if presence[c.operator] {
+			slice = append(slice, c)
+			continue
+		}
+		elem = append(elem, c)
+	}
+	return slice, elem
+}
+
+// cutDive splits cond around its first "dive".
+func cutDive(cond []Condition) (before, after []Condition, found bool) {
+	for i, c := range cond {
+		if c.operator == "dive" && !c.negated {
+			return cond[:i], cond[i+1:], true
+		}
+	}
+	return cond, nil, false
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// compileRule compiles c for values of type t held by a struct of type parent.
+func (v *Validator) compileRule(c Condition, parent, t reflect.Type) (rule, error) {
+	if c.negated {
+		return v.negatedRule(c, parent, t)
+	}
+	if c.anyOf != nil {
+		return v.anyOfRule(c, parent, t)
+	}
+	if r, ok := v.customRule(c); ok {
+		return r, nil
+	}
+	if _, ok := fieldComparisons[c.operator]; ok {
+		return crossFieldRule(c, parent, t, v.nameOf)
+	}
+	switch t {
+	case timeType:
+		return timeRule(c, v.now)
+	case durationType:
+		return durationRule(c)
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Struct:
+		return structRule(c)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return sliceRule(c)
+	case reflect.String:
+		return stringRule(c, v.byteLen)
+	case reflect.Bool:
+		return boolRule(c)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intRule(c, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return uintRule(c, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		return floatRule(c, t.Bits())
+	default:
+	}
+	return rule{}, ErrUnsupType
+}
+
+// dynamicPlan compiles the tag of an interface for the dynamic types of its
+// values, on first use.
+type dynamicPlan struct {
+	parent reflect.Type
+	cond   []Condition
+	plans  sync.Map // reflect.Type -> dynamicEntry
+}
+
+// dynamicEntry is the tag of an interface compiled for a dynamic type, err is
+// set when the tag doesn't fit the type.
+type dynamicEntry struct {
+	p   *valuePlan
+	err error
+}
+
+// planOf returns the plan for values of type t. The error wraps
+// ErrUnsupType and the reason the tag doesn't fit t, it's cached as well.
+func (d *dynamicPlan) planOf(v *Validator, t reflect.Type) (*valuePlan, error) {
+	if e, ok := d.plans.Load(t); ok {
+		return e.(dynamicEntry).p, e.(dynamicEntry).err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	p, err := v.newValuePlan(d.parent, t, d.cond)
+	if err != nil {
+		err = fmt.Errorf("%w %s: %w", ErrUnsupType, t, err)
+	}
+	e, _ := d.plans.LoadOrStore(t, dynamicEntry{p: p, err: err})
+	return e.(dynamicEntry).p, e.(dynamicEntry).err
+}
+
+// errStop ends a walk in the fail-fast mode once a failure is collected.
+var errStop = errors.New("stop validation")
+
+// walker collects the failures of a single Validate call.
+type walker struct {
+	v   *Validator
+	ctx context.Context
+	ve  ValidationErrors
+}
+
+// validateStruct validates the fields of the struct v, found at path. The
+// fields of a nil embedded pointer are skipped.
+func (w *walker) validateStruct(v reflect.Value, p *structPlan, path Path) error {
+	for _, f := range p.fields {
+		holder := v
+		if f.embed != nil {
+			e, err := v.FieldByIndexErr(f.embed)
+			if err != nil {
+				continue
+			}
+			var ok bool
+			if holder, ok = indirect(e); !ok {
+				continue
+			}
+		}
+		fieldPath := append(append(path, f.via...), PathElem{Name: f.name})
+		if err := w.validateValue(holder.Field(f.index), f.valuePlan, fieldPath, holder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValue validates v, found at path in the parent struct, against p.
+// An empty value fails "required" and skips the rest of p when it's
+// "omitempty" or conditionally required, a nil pointer is always skipped.
+func (w *walker) validateValue(v reflect.Value, p *valuePlan, path Path, parent reflect.Value) error {
+	if isEmpty(v) {
+		if p.required || p.requiredBy(parent) {
+			return w.fail(path, ErrRequired)
+		}
+		if p.omitempty || len(p.requiredIf) != 0 {
+			return nil
+		}
+	}
+	v, ok := indirect(v)
+	if !ok {
+		return nil
+	}
+	if p.dynamic != nil {
+		dp, err := p.dynamic.planOf(w.v, v.Type())
+		if err != nil {
+			return w.fail(path, err)
+		}
+		return w.validateValue(v, dp, path, parent)
+	}
+
+	fl := FieldLevel{Context: w.ctx, Value: v, Parent: parent}
+	for _, r := range p.rules {
+		fl.Operand = r.operand
+		if err := r.check(fl); err != nil {
+			if err := w.fail(path, err); err != nil {
+				return err
+			}
+		}
+	}
+	if p.nested {
+		if err := w.validateStructField(v, path); err != nil {
+			return err
+		}
+	}
+	if v.Kind() == reflect.Map {
+		if err := w.validateMap(v, p, path, parent); err != nil {
+			return err
+		}
+	} else if p.elem != nil {
+		for i := 0; i < v.Len(); i++ {
+			if err := w.validateValue(v.Index(i), p.elem, append(path, PathElem{Index: i}), parent); err != nil {
+				return err
+			}
+		}
+	}
+	if p.hook != nil {
+		return w.validateHook(v, p.hook, path)
+	}
+	return nil
+}
+
+// isEmpty reports whether v is a nil pointer or interface, an empty slice or
+// map, or the zero value of any other kind. A non-nil pointer isn't empty even
+// if it points to a zero value, an interface holding a nil pointer is.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr:
+		return v.IsNil()
+	case reflect.Interface:
+		return v.IsNil() || v.Elem().Kind() == reflect.Ptr && v.Elem().IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// fail collects err found at path. It returns errStop when the walk must end.
+func (w *walker) fail(path Path, err error) error {
+	w.ve = append(w.ve, newValidationError(path, err))
+	if w.v.failFast {
+		return errStop
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package struct_validator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStructPlan(t *testing.T) {
+	testStruct := struct {
+		StrTag     string `validate:"in:foo|regexp:foo|len:3"`
+		IntTag     int    `validate:"in:10,20|max:20|min:30"`
+		unexpField int    `validate:"in:10,20|max:20|min:30"`
+		JSONTag    string `json:"id"`
+		NoTag      string
+	}{"string", 0, 0, "json", "notag"}
+
+	type field struct {
+		index int
+		name  string
+		rules []Condition
+	}
+	tests := []struct {
+		name   string
+		st     interface{}
+		fields []field
+		err    error
+	}{
+		{
+			name: "common case",
+			st:   testStruct,
+			fields: []field{
+				{0, "StrTag", []Condition{cond("in", "foo"), cond("regexp", "foo"), cond("len", "3")}},
+				{1, "IntTag", []Condition{cond("in", "10,20"), cond("max", "20"), cond("min", "30")}},
+			},
+			err: nil,
+		},
+		{
+			name:   "type error",
+			st:     "string",
+			fields: nil,
+			err:    ErrType,
+		},
+		{
+			name: "condition error",
+			st: struct {
+				IntTag int `validate:"len:3"`
+			}{},
+			fields: nil,
+			err:    ErrUnsupCondition,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := defaultValidator.planOf(reflect.TypeOf(tc.st))
+			require.Equal(t, tc.err, err)
+			if err != nil {
+				return
+			}
+			require.Len(t, plan.fields, len(tc.fields))
+			for i, f := range plan.fields {
+				require.Equal(t, tc.fields[i].index, f.index)
+				require.Equal(t, tc.fields[i].name, f.name)
+				require.Len(t, f.rules, len(tc.fields[i].rules))
+				for j, r := range f.rules {
+					require.Equal(t, tc.fields[i].rules[j], r.Condition)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanCache(t *testing.T) {
+	typ := reflect.TypeOf(User{})
+	defaultValidator.plans.Delete(typ)
+
+	first, err := defaultValidator.planOf(typ)
+	require.NoError(t, err)
+
+	plans := make([]*structPlan, 8)
+	errs := make([]error, len(plans))
+	var wg sync.WaitGroup
+	for i := range plans {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			plans[i], errs[i] = defaultValidator.planOf(typ)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, p := range plans {
+		require.NoError(t, errs[i])
+		require.Same(t, first, p)
+	}
+}
+
+func benchmarkUser() User {
+	return User{
+		ID:     "7f0e3265-ca96-4b33-8858-fef9696cc71b",
+		Name:   "Name",
+		Age:    30,
+		Email:  "somemail@gmail.com",
+		Role:   "admin",
+		Phones: []string{"12345678901", "10987654321"},
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	u := benchmarkUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := Validate(u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateUncached(b *testing.B) {
+	u := benchmarkUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v := New()
+		if err := v.Validate(u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
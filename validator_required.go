@@ -0,0 +1,175 @@
+package struct_validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// presence operators decide whether a value must be set instead of checking it.
+var presence = map[string]bool{
+	"required":         true,
+	"omitempty":        true,
+	"required_if":      true,
+	"required_with":    true,
+	"required_without": true,
+}
+
+// requirement reports whether a value is required given the struct holding it.
+type requirement func(parent reflect.Value) bool
+
+// requiredBy reports whether any conditional requirement of p holds.
+func (p *valuePlan) requiredBy(parent reflect.Value) bool {
+	for _, req := range p.requiredIf {
+		if req(parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// requirementOf compiles a conditional requirement. Its operand names fields
+// of parent by their Go names, a dotted path reaches into nested structs:
+//
+//	required_if:Field,value[,Field,value...] - every Field equals its value
+//	required_with:Field[,Field...]           - any Field is not empty
+//	required_without:Field[,Field...]        - any Field is empty
+func requirementOf(c Condition, parent reflect.Type) (requirement, error) {
+	if c.operand == "" {
+		return nil, ErrInvalidOperand
+	}
+
+	switch c.operator {
+	case "required_if":
+		if len(c.params)%2 != 0 {
+			return nil, ErrInvalidOperand
+		}
+		fields := make([]fieldRef, 0, len(c.params)/2)
+		values := make([]func(reflect.Value) bool, 0, len(c.params)/2)
+		for i := 0; i < len(c.params); i += 2 {
+			f, err := resolveField(parent, c.params[i])
+			if err != nil {
+				return nil, err
+			}
+			eq, err := equalsOperand(f.typ, c.params[i+1])
+			if err != nil {
+				return nil, err
+			}
+			fields, values = append(fields, f), append(values, eq)
+		}
+		return func(parent reflect.Value) bool {
+			for i, f := range fields {
+				if !values[i](f.lookup(parent)) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "required_with", "required_without":
+		fields := make([]fieldRef, len(c.params))
+		for i, name := range c.params {
+			f, err := resolveField(parent, name)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = f
+		}
+		with := c.operator == "required_with"
+		return func(parent reflect.Value) bool {
+			for _, f := range fields {
+				if isEmpty(f.lookup(parent)) != with {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return nil, ErrUnsupCondition
+	}
+}
+
+// fieldRef is a field found by a dotted path of Go field names.
+type fieldRef struct {
+	index  [][]int // of the field at every step of the path
+	fields []reflect.StructField
+	typ    reflect.Type
+}
+
+func resolveField(parent reflect.Type, path string) (fieldRef, error) {
+	f := fieldRef{typ: parent}
+	if parent == nil {
+		return f, ErrInvalidOperand // a value outside of a struct
+	}
+	for _, name := range strings.Split(path, ".") {
+		t := indirectType(f.typ)
+		if t.Kind() != reflect.Struct {
+			return f, ErrInvalidOperand
+		}
+		sf, ok := t.FieldByName(name)
+		if !ok || !sf.IsExported() {
+			return f, ErrInvalidOperand
+		}
+		f.index = append(f.index, sf.Index)
+		f.fields = append(f.fields, sf)
+		f.typ = sf.Type
+	}
+	return f, nil
+}
+
+// lookup returns the field in parent, or an invalid Value if a nil pointer is
+// met on the way.
+func (f fieldRef) lookup(parent reflect.Value) reflect.Value {
+	v := parent
+	for _, index := range f.index {
+		var ok bool
+		if v, ok = indirect(v); !ok {
+			return reflect.Value{}
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(index); err != nil {
+			return reflect.Value{}
+		}
+	}
+	return v
+}
+
+// equalsOperand returns a func reporting whether a value of type t equals
+// operand. A nil pointer equals nothing.
+func equalsOperand(t reflect.Type, operand string) (func(reflect.Value) bool, error) {
+	var eq func(reflect.Value) bool
+	switch t := indirectType(t); t.Kind() { //nolint:exhaustive
+	case reflect.String:
+		eq = func(v reflect.Value) bool { return v.String() == operand }
+	case reflect.Bool:
+		b, err := strconv.ParseBool(operand)
+		if err != nil {
+			return nil, err
+		}
+		eq = func(v reflect.Value) bool { return v.Bool() == b }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(operand, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		eq = func(v reflect.Value) bool { return v.Int() == i }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(operand, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		eq = func(v reflect.Value) bool { return v.Uint() == u }
+	case reflect.Float32, reflect.Float64:
+		f, err := parseFloat(operand, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		eq = func(v reflect.Value) bool { return v.Float() == f }
+	default:
+		return nil, ErrUnsupType
+	}
+
+	return func(v reflect.Value) bool {
+		v, ok := indirect(v)
+		return ok && v.IsValid() && eq(v)
+	}, nil
+}
@@ -0,0 +1,176 @@
+package struct_validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequiredIf(t *testing.T) {
+	type (
+		Address struct {
+			Country string
+			Zip     *int
+		}
+
+		Account struct {
+			AccountType string
+			Employees   int
+			Verified    bool
+			Address     *Address
+			CompanyName string   `validate:"required_if:AccountType,business|len:4"`
+			TaxID       string   `validate:"required_if:AccountType,business,Employees,10"`
+			State       string   `validate:"required_if:Address.Country,US"`
+			Documents   []string `validate:"required_if:Verified,true"`
+			Phone       string   `validate:"required_without:Email"`
+			Email       string   `validate:"required_without:Phone"`
+			ZipNote     string   `validate:"required_with:Address.Zip"`
+			Refs        []string `validate:"dive|required_with:Phone"`
+		}
+	)
+	zip := 90210
+
+	tests := []struct {
+		name        string
+		in          Account
+		expectedErr error
+	}{
+		{
+			name:        "conditions don't hold",
+			in:          Account{AccountType: "personal", Email: "bob@example.com"},
+			expectedErr: nil,
+		},
+		{
+			name: "conditions hold",
+			in: Account{
+				AccountType: "business",
+				Employees:   10,
+				Verified:    true,
+				Address:     &Address{Country: "US", Zip: &zip},
+				Phone:       "+15550100",
+				Refs:        []string{"ref", ""},
+			},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "CompanyName"),
+				fieldError(ErrRequired, "TaxID"),
+				fieldError(ErrRequired, "State"),
+				fieldError(ErrRequired, "Documents"),
+				fieldError(ErrRequired, "ZipNote"),
+				fieldError(ErrRequired, "Refs", 1),
+			},
+		},
+		{
+			name: "conditions hold and values are set",
+			in: Account{
+				AccountType: "business",
+				Employees:   10,
+				Address:     &Address{Country: "US", Zip: &zip},
+				CompanyName: "Acme",
+				TaxID:       "123",
+				State:       "CA",
+				Email:       "bob@example.com",
+				ZipNote:     "note",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "values set without the condition are validated",
+			in:   Account{AccountType: "personal", CompanyName: "Acme Inc", Email: "bob@example.com"},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationStrLen, "CompanyName"),
+			},
+		},
+		{
+			name:        "only some of the values match",
+			in:          Account{AccountType: "business", Employees: 5, CompanyName: "Acme", Phone: "+15550100"},
+			expectedErr: nil,
+		},
+		{
+			name: "required without any",
+			in:   Account{Address: &Address{Country: "DE"}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "Phone"),
+				fieldError(ErrRequired, "Email"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
+		})
+	}
+}
+
+func TestValidateRequiredIfNested(t *testing.T) {
+	type (
+		Shipping struct {
+			Method  string
+			Address string `validate:"required_if:Method,courier"`
+		}
+
+		Order struct {
+			Shipping  Shipping   `validate:"nested"`
+			Gifts     []Shipping `validate:"dive|nested"`
+			GiftCount int
+			Message   string `validate:"required_if:Shipping.Method,courier,GiftCount,0"`
+		}
+	)
+
+	err := Validate(Order{
+		Shipping: Shipping{Method: "courier"},
+		Gifts:    []Shipping{{Method: "pickup"}, {Method: "courier"}},
+	})
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrRequired, "Shipping", "Address"),
+		fieldError(ErrRequired, "Gifts", 1, "Address"),
+		fieldError(ErrRequired, "Message"),
+	}, err)
+}
+
+func TestValidateRequiredIfErrors(t *testing.T) {
+	type (
+		Inner struct {
+			Name string
+		}
+
+		Unknown struct {
+			Name string `validate:"required_if:Missing,x"`
+		}
+		Unexported struct {
+			name string
+			Name string `validate:"required_with:name"`
+		}
+		NotStruct struct {
+			Kind string
+			Name string `validate:"required_if:Kind.Name,x"`
+		}
+		OddParams struct {
+			Kind string
+			Name string `validate:"required_if:Kind,a,Kind"`
+		}
+		NoOperand struct {
+			Name string `validate:"required_without"`
+		}
+		Negated struct {
+			Kind string
+			Name string `validate:"!required_with:Kind"`
+		}
+		Unsupported struct {
+			Inner Inner
+			Name  string `validate:"required_if:Inner,x"`
+		}
+	)
+
+	for _, s := range []interface{}{Unknown{}, Unexported{}, NotStruct{}, OddParams{}, NoOperand{}} {
+		require.ErrorIs(t, Validate(s), ErrInvalidOperand, "%T", s)
+	}
+	require.ErrorIs(t, Validate(Negated{}), ErrUnsupCondition)
+	require.ErrorIs(t, Validate(Unsupported{}), ErrUnsupType)
+
+	type BadInt struct {
+		Count int8
+		Name  string `validate:"required_if:Count,300"`
+	}
+	require.Error(t, Validate(BadInt{}))
+}
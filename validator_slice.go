@@ -0,0 +1,59 @@
+package struct_validator
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	ErrValidationLen    = errors.New("validation error, length is not as expected")
+	ErrValidationMinLen = errors.New("validation error, length less than expected")
+	ErrValidationMaxLen = errors.New("validation error, length greater than expected")
+)
+
+// sliceRule compiles c for the length of a slice, an array or a map.
+func sliceRule(c Condition) (rule, error) {
+	switch c.operator {
+	case "len":
+		l, err := strconv.Atoi(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateLen(fl.Value.Len(), l) }}, nil
+	case "minlen":
+		l, err := strconv.Atoi(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateMinLen(fl.Value.Len(), l) }}, nil
+	case "maxlen":
+		l, err := strconv.Atoi(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateMaxLen(fl.Value.Len(), l) }}, nil
+	default:
+		return rule{}, ErrUnsupCondition
+	}
+}
+
+func validateLen(l int, expLen int) error {
+	if l != expLen {
+		return ErrValidationLen
+	}
+	return nil
+}
+
+func validateMinLen(l int, min int) error {
+	if l < min {
+		return ErrValidationMinLen
+	}
+	return nil
+}
+
+func validateMaxLen(l int, max int) error {
+	if l > max {
+		return ErrValidationMaxLen
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package struct_validator
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSliceField(t *testing.T) {
+	type in struct {
+		tag   string
+		value interface{}
+		name  string
+	}
+	tests := []struct {
+		name  string
+		input in
+		err   error
+	}{
+		{
+			name:  "empty slice",
+			input: in{tag: "len:11", value: []string{}, name: "Phones"},
+			err:   nil,
+		},
+		{
+			name:  "nil slice",
+			input: in{tag: "min:1", value: []int(nil), name: "IDs"},
+			err:   nil,
+		},
+		{
+			name:  "slice length",
+			input: in{tag: "minlen:1|maxlen:3|dive|len:3", value: []string{"foo", "bar"}, name: "Tags"},
+			err:   nil,
+		},
+		{
+			name:  "slice length err",
+			input: in{tag: "minlen:1|dive|len:3", value: []string{}, name: "Tags"},
+			err: ValidationErrors{
+				fieldError(ErrValidationMinLen, "Tags"),
+			},
+		},
+		{
+			name:  "slice and element err",
+			input: in{tag: "len:2|maxlen:1|dive|len:3", value: []string{"foo", "bar", "bazz"}, name: "Tags"},
+			err: ValidationErrors{
+				fieldError(ErrValidationLen, "Tags"),
+				fieldError(ErrValidationMaxLen, "Tags"),
+				fieldError(ErrValidationStrLen, "Tags", 2),
+			},
+		},
+		{
+			name:  "slice conditions only",
+			input: in{tag: "maxlen:2|dive", value: []bool{true, false, true}, name: "Flags"},
+			err: ValidationErrors{
+				fieldError(ErrValidationMaxLen, "Flags"),
+			},
+		},
+		{
+			name:  "float elements",
+			input: in{tag: "min:0", value: []float32{1, -1}, name: "Prices"},
+			err: ValidationErrors{
+				fieldError(ErrValidationFloatMin, "Prices", 1),
+			},
+		},
+		{
+			name:  "slice of slices",
+			input: in{tag: "maxlen:2|dive|minlen:1|dive|in:a,b", value: [][]string{{"a"}, {}, {"c"}}, name: "Matrix"},
+			err: ValidationErrors{
+				fieldError(ErrValidationMaxLen, "Matrix"),
+				fieldError(ErrValidationMinLen, "Matrix", 1),
+				fieldError(ErrValidationStrIn, "Matrix", 2, 0),
+			},
+		},
+		{
+			name:  "slice of structs",
+			input: in{tag: "nested", value: []App{{Version: "debug"}, {Version: "release"}}, name: "Apps"},
+			err: ValidationErrors{
+				fieldError(ErrValidationStrLen, "Apps", 1, "Version"),
+			},
+		},
+		{
+			name:  "slice of struct pointers",
+			input: in{tag: "minlen:2|dive|nested", value: []*App{{Version: "debug"}, nil}, name: "Apps"},
+			err:   nil,
+		},
+		{
+			name:  "element condition before dive",
+			input: in{tag: "regexp:foo|dive", value: []string{"foo"}, name: "Tags"},
+			err:   ErrUnsupCondition,
+		},
+		{
+			name:  "dive on a non slice",
+			input: in{tag: "dive|len:3", value: "foo", name: "Tag"},
+			err:   ErrUnsupCondition,
+		},
+		{
+			name:  "strconv err",
+			input: in{tag: "minlen:one|dive", value: []string{}, name: "Tags"},
+			err:   strconv.ErrSyntax,
+		},
+		{
+			name:  "array length",
+			input: in{tag: "minlen:2|maxlen:3|dive", value: [4]int{}, name: "Point"},
+			err: ValidationErrors{
+				fieldError(ErrValidationMaxLen, "Point"),
+			},
+		},
+		{
+			name:  "array elements",
+			input: in{tag: "min:1|max:9", value: [3]int{1, 0, 10}, name: "Digits"},
+			err: ValidationErrors{
+				fieldError(ErrValidationIntMin, "Digits", 1),
+				fieldError(ErrValidationIntMax, "Digits", 2),
+			},
+		},
+		{
+			name:  "array and element err",
+			input: in{tag: "len:2|dive|len:3", value: [3]string{"foo", "bar", "bazz"}, name: "Tags"},
+			err: ValidationErrors{
+				fieldError(ErrValidationLen, "Tags"),
+				fieldError(ErrValidationStrLen, "Tags", 2),
+			},
+		},
+		{
+			name:  "array of arrays",
+			input: in{tag: "dive|dive|max:1", value: [2][2]uint8{{0, 1}, {2, 0}}, name: "Grid"},
+			err: ValidationErrors{
+				fieldError(ErrValidationIntMax, "Grid", 1, 0),
+			},
+		},
+		{
+			name:  "array of slices",
+			input: in{tag: "dive|minlen:1|dive|in:a", value: [2][]string{{"a"}, {}}, name: "Rows"},
+			err: ValidationErrors{
+				fieldError(ErrValidationMinLen, "Rows", 1),
+			},
+		},
+		{
+			name:  "array of struct pointers",
+			input: in{tag: "nested", value: [2]*App{{Version: "debug"}, {Version: "beta"}}, name: "Apps"},
+			err: ValidationErrors{
+				fieldError(ErrValidationStrLen, "Apps", 1, "Version"),
+			},
+		},
+		{
+			name:  "map length",
+			input: in{tag: "minlen:2|maxlen:3", value: map[string]int{"a": 1}, name: "Quotas"},
+			err: ValidationErrors{
+				fieldError(ErrValidationMinLen, "Quotas"),
+			},
+		},
+		{
+			name:  "map exact length",
+			input: in{tag: "len:1", value: map[string]int{"a": 1}, name: "Quotas"},
+			err:   nil,
+		},
+	}
+
+	var verr ValidationErrors
+	var serr *strconv.NumError
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTagged(tc.input.name, tc.input.tag, tc.input.value)
+			if errors.As(err, &verr) {
+				require.Equal(t, tc.err, verr)
+				return
+			}
+			if errors.As(err, &serr) {
+				require.Equal(t, tc.err, serr.Err)
+				return
+			}
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -5,80 +5,167 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 var (
 	ErrValidationStrLen    = errors.New("validation error, string's length is not as expected")
+	ErrValidationStrMinLen = errors.New("validation error, string's length less than expected")
+	ErrValidationStrMaxLen = errors.New("validation error, string's length greater than expected")
 	ErrValidationStrRegexp = errors.New("validation error, string doesn't match the regexp")
-	ErrValidationStrIn     = errors.New("validation error, string doesn't match the substring")
+	ErrValidationStrIn     = errors.New("validation error, string doesn't match any of the allowed values")
+
+	ErrValidationStrStartsWith = errors.New("validation error, string doesn't start with the prefix")
+	ErrValidationStrEndsWith   = errors.New("validation error, string doesn't end with the suffix")
+	ErrValidationStrContains   = errors.New("validation error, string doesn't contain the substring")
+	ErrValidationStrExcludes   = errors.New("validation error, string contains the substring")
+	ErrValidationStrLowercase  = errors.New("validation error, string isn't lowercase")
+	ErrValidationStrUppercase  = errors.New("validation error, string isn't uppercase")
+	ErrValidationStrASCII      = errors.New("validation error, string isn't ASCII")
+	ErrValidationStrPrintable  = errors.New("validation error, string isn't printable")
 )
 
-func validateStringField(tag string, value []string, fieldName string) error {
-	var verr ValidationErrors
-	var err error
-	ve := ValidationErrors{}
+// stringMatcher checks a string against each value of the operand, any of
+// them must match unless none is set.
+type stringMatcher struct {
+	match func(s, value string) bool
+	none  bool
+	err   error
+}
 
-	cond, err := parseConditions(tag)
-	if err != nil {
-		return err
+var stringMatchers = map[string]stringMatcher{
+	"startswith": {match: strings.HasPrefix, err: ErrValidationStrStartsWith},
+	"endswith":   {match: strings.HasSuffix, err: ErrValidationStrEndsWith},
+	"contains":   {match: strings.Contains, err: ErrValidationStrContains},
+	"excludes":   {match: strings.Contains, none: true, err: ErrValidationStrExcludes},
+}
+
+// stringClasses are the operators checking every rune of a string, they take
+// no operand.
+var stringClasses = map[string]struct {
+	is  func(r rune) bool
+	err error
+}{
+	"lowercase": {is: func(r rune) bool { return !unicode.IsUpper(r) && !unicode.IsTitle(r) }, err: ErrValidationStrLowercase},
+	"uppercase": {is: func(r rune) bool { return !unicode.IsLower(r) && !unicode.IsTitle(r) }, err: ErrValidationStrUppercase},
+	"ascii":     {is: func(r rune) bool { return r <= unicode.MaxASCII }, err: ErrValidationStrASCII},
+	"printable": {is: unicode.IsPrint, err: ErrValidationStrPrintable},
+}
+
+// stringRule compiles c for a string. Lengths are counted in runes, or in
+// bytes if byteLen is set.
+func stringRule(c Condition, byteLen bool) (rule, error) {
+	length := utf8.RuneCountInString
+	if byteLen {
+		length = func(s string) int { return len(s) }
 	}
 
-	for _, v := range value {
-		for _, c := range cond {
-			switch c.operator {
-			case "len":
-				err = validateStringLen(v, c.operand, fieldName)
-			case "regexp":
-				err = validateStringRegexp(v, c.operand, fieldName)
-			case "in":
-				err = validateStringIn(v, c.operand, fieldName)
-			default:
-				return ErrUnsupCondition
-			}
-			if err != nil {
-				if !errors.As(err, &verr) {
-					return err
-				}
-				ve = append(ve, verr...)
-			}
+	switch c.operator {
+	case "len":
+		l, err := strconv.Atoi(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateStringLen(length(fl.Value.String()), l) }}, nil
+	case "minlen":
+		l, err := strconv.Atoi(c.operand)
+		if err != nil {
+			return rule{}, err
 		}
+		return rule{c, func(fl FieldLevel) error { return validateStringMinLen(length(fl.Value.String()), l) }}, nil
+	case "maxlen":
+		l, err := strconv.Atoi(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateStringMaxLen(length(fl.Value.String()), l) }}, nil
+	case "regexp":
+		re, err := regexp.Compile(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateStringRegexp(fl.Value.String(), re) }}, nil
+	case "in":
+		return rule{c, func(fl FieldLevel) error { return validateStringIn(fl.Value.String(), c.params, false) }}, nil
+	case "in_ci":
+		return rule{c, func(fl FieldLevel) error { return validateStringIn(fl.Value.String(), c.params, true) }}, nil
+	}
+
+	if m, ok := stringMatchers[c.operator]; ok {
+		if c.operand == "" {
+			return rule{}, ErrInvalidOperand
+		}
+		return rule{c, func(fl FieldLevel) error { return validateStringMatch(fl.Value.String(), c.params, m) }}, nil
 	}
-	if len(ve) > 0 {
-		return ve
+	if class, ok := stringClasses[c.operator]; ok {
+		if c.operand != "" {
+			return rule{}, ErrInvalidOperand
+		}
+		return rule{c, func(fl FieldLevel) error { return validateStringClass(fl.Value.String(), class.is, class.err) }}, nil
 	}
-	return err
+	return formatRule(c)
 }
 
-func validateStringLen(field string, elen string, name string) error {
-	expLen, err := strconv.Atoi(elen)
-	if err != nil {
-		return err
+func validateStringLen(l int, expLen int) error {
+	if l != expLen {
+		return ErrValidationStrLen
 	}
-	if len(field) != expLen {
-		return ValidationErrors{ValidationError{Field: name, Err: ErrValidationStrLen}}
+	return nil
+}
+
+func validateStringMinLen(l int, min int) error {
+	if l < min {
+		return ErrValidationStrMinLen
 	}
 	return nil
 }
 
-func validateStringRegexp(field string, regxp string, name string) error {
-	re, err := regexp.Compile(regxp)
-	if err != nil {
-		return err
+func validateStringMaxLen(l int, max int) error {
+	if l > max {
+		return ErrValidationStrMaxLen
 	}
+	return nil
+}
+
+func validateStringRegexp(field string, re *regexp.Regexp) error {
 	if !re.MatchString(field) {
-		return ValidationErrors{ValidationError{Field: name, Err: ErrValidationStrRegexp}}
+		return ErrValidationStrRegexp
 	}
 	return nil
 }
 
-func validateStringIn(field string, in string, name string) error {
-	if field == "" && in == "" {
-		return nil
+// validateStringIn checks field is one of in, ignoring Unicode case if fold
+// is set.
+func validateStringIn(field string, in []string, fold bool) error {
+	for _, is := range in {
+		if field == is || fold && strings.EqualFold(field, is) {
+			return nil
+		}
 	}
-	for _, is := range strings.Split(in, inSplitSymbol) {
-		if field == is {
+	return ErrValidationStrIn
+}
+
+func validateStringMatch(field string, values []string, m stringMatcher) error {
+	for _, value := range values {
+		if m.match(field, value) {
+			if m.none {
+				return m.err
+			}
 			return nil
 		}
 	}
-	return ValidationErrors{ValidationError{Field: name, Err: ErrValidationStrIn}}
+	if m.none {
+		return nil
+	}
+	return m.err
+}
+
+func validateStringClass(field string, is func(r rune) bool, err error) error {
+	for _, r := range field {
+		if !is(r) {
+			return err
+		}
+	}
+	return nil
 }
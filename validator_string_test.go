@@ -28,7 +28,7 @@ func TestValidateStringField(t *testing.T) {
 			name:  "validate err len",
 			input: in{tag: "len:2", value: []string{"foo"}, name: "len"},
 			err: ValidationErrors{
-				ValidationError{Field: "len", Err: ErrValidationStrLen},
+				fieldError(ErrValidationStrLen, "len", 0),
 			},
 		},
 		{
@@ -40,7 +40,7 @@ func TestValidateStringField(t *testing.T) {
 			name:  "validate err regexp",
 			input: in{tag: "regexp:regarg", value: []string{"^\\w+@\\w+.com$"}, name: "regexp"},
 			err: ValidationErrors{
-				ValidationError{Field: "regexp", Err: ErrValidationStrRegexp},
+				fieldError(ErrValidationStrRegexp, "regexp", 0),
 			},
 		},
 		{
@@ -52,7 +52,7 @@ func TestValidateStringField(t *testing.T) {
 			name:  "validate err in",
 			input: in{tag: "in:foo", value: []string{"bar"}, name: "in"},
 			err: ValidationErrors{
-				ValidationError{Field: "in", Err: ErrValidationStrIn},
+				fieldError(ErrValidationStrIn, "in", 0),
 			},
 		},
 		{
@@ -64,9 +64,9 @@ func TestValidateStringField(t *testing.T) {
 			name:  "validate err multi conditions",
 			input: in{tag: "in:bar|regexp:bar|len:6", value: []string{"foo"}, name: "multi"},
 			err: ValidationErrors{
-				ValidationError{Field: "multi", Err: ErrValidationStrIn},
-				ValidationError{Field: "multi", Err: ErrValidationStrRegexp},
-				ValidationError{Field: "multi", Err: ErrValidationStrLen},
+				fieldError(ErrValidationStrIn, "multi", 0),
+				fieldError(ErrValidationStrRegexp, "multi", 0),
+				fieldError(ErrValidationStrLen, "multi", 0),
 			},
 		},
 		{
@@ -78,12 +78,12 @@ func TestValidateStringField(t *testing.T) {
 			name:  "validate err slice multi conditions",
 			input: in{tag: "in:tmp|regexp:^\\d+$|len:6", value: []string{"foo", "bar"}, name: "multi"},
 			err: ValidationErrors{
-				ValidationError{Field: "multi", Err: ErrValidationStrIn},
-				ValidationError{Field: "multi", Err: ErrValidationStrRegexp},
-				ValidationError{Field: "multi", Err: ErrValidationStrLen},
-				ValidationError{Field: "multi", Err: ErrValidationStrIn},
-				ValidationError{Field: "multi", Err: ErrValidationStrRegexp},
-				ValidationError{Field: "multi", Err: ErrValidationStrLen},
+				fieldError(ErrValidationStrIn, "multi", 0),
+				fieldError(ErrValidationStrRegexp, "multi", 0),
+				fieldError(ErrValidationStrLen, "multi", 0),
+				fieldError(ErrValidationStrIn, "multi", 1),
+				fieldError(ErrValidationStrRegexp, "multi", 1),
+				fieldError(ErrValidationStrLen, "multi", 1),
 			},
 		},
 		{
@@ -102,7 +102,7 @@ func TestValidateStringField(t *testing.T) {
 	var serr *strconv.NumError
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateStringField(tc.input.tag, tc.input.value, tc.input.name)
+			err := validateTagged(tc.input.name, tc.input.tag, tc.input.value)
 			if errors.As(err, &verr) {
 				require.Equal(t, tc.err, verr)
 				return
@@ -128,17 +128,14 @@ func TestValidateStringLen(t *testing.T) {
 		{name: "empty string", testString: "", expectedLen: "0", err: nil},
 		{name: "common case", testString: "string len", expectedLen: "10", err: nil},
 		{name: "validate error", testString: "string len", expectedLen: "9", err: ErrValidationStrLen},
+		{name: "runes", testString: "Дмитрий", expectedLen: "7", err: nil},
+		{name: "runes, bytes expected", testString: "Дмитрий", expectedLen: "14", err: ErrValidationStrLen},
 	}
 
-	var verr ValidationErrors
 	var serr *strconv.NumError
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateStringLen(tc.testString, tc.expectedLen, tc.name)
-			if errors.As(err, &verr) {
-				require.Equal(t, tc.err, verr[0].Err)
-				return
-			}
+			err := checkRule("len:"+tc.expectedLen, tc.testString)
 			if errors.As(err, &serr) {
 				require.Equal(t, tc.err, serr.Err)
 				return
@@ -148,6 +145,42 @@ func TestValidateStringLen(t *testing.T) {
 	}
 }
 
+func TestValidateStringLenRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		testString string
+		tag        string
+		err        error
+	}{
+		{name: "min", testString: "foo", tag: "minlen:3", err: nil},
+		{name: "min error", testString: "fo", tag: "minlen:3", err: ErrValidationStrMinLen},
+		{name: "max", testString: "foo", tag: "maxlen:3", err: nil},
+		{name: "max error", testString: "fooo", tag: "maxlen:3", err: ErrValidationStrMaxLen},
+		{name: "range", testString: "Ёжик", tag: "minlen:2|maxlen:4", err: nil},
+		{name: "range, min error", testString: "Ё", tag: "minlen:2|maxlen:4", err: ErrValidationStrMinLen},
+		{name: "range, max error", testString: "Ёжики", tag: "minlen:2|maxlen:4", err: ErrValidationStrMaxLen},
+		{name: "combining marks are runes", testString: "e\u0301", tag: "maxlen:1", err: ErrValidationStrMaxLen},
+		{name: "invalid operand", testString: "foo", tag: "maxlen:x", err: strconv.ErrSyntax},
+	}
+
+	var verr ValidationErrors
+	var serr *strconv.NumError
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTagged("Name", tc.tag, tc.testString)
+			switch {
+			case errors.As(err, &verr):
+				require.Len(t, verr, 1)
+				require.Equal(t, tc.err, verr[0].Err)
+			case errors.As(err, &serr):
+				require.Equal(t, tc.err, serr.Err)
+			default:
+				require.Equal(t, tc.err, err)
+			}
+		})
+	}
+}
+
 func TestValidateStringRegexp(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -162,14 +195,9 @@ func TestValidateStringRegexp(t *testing.T) {
 		{name: "validate error", testString: "error case", regexp: "foo", err: ErrValidationStrRegexp},
 	}
 
-	var verr ValidationErrors
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateStringRegexp(tc.testString, tc.regexp, tc.name)
-			if errors.As(err, &verr) {
-				require.Equal(t, tc.err, verr[0].Err)
-				return
-			}
+			err := checkRule("regexp:"+tc.regexp, tc.testString)
 			require.Equal(t, tc.err, err)
 		})
 	}
@@ -190,15 +218,73 @@ func TestValidateStringIn(t *testing.T) {
 		{name: "validate error", testString: "error case", subString: "а", err: ErrValidationStrIn},
 	}
 
-	var verr ValidationErrors
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateStringIn(tc.testString, tc.subString, tc.name)
-			if errors.As(err, &verr) {
-				require.Equal(t, tc.err, verr[0].Err)
-				return
-			}
+			err := checkRule("in:"+tc.subString, tc.testString)
 			require.Equal(t, tc.err, err)
 		})
 	}
 }
+
+func TestValidateStringInFold(t *testing.T) {
+	tests := []struct {
+		name       string
+		testString string
+		values     string
+		err        error
+	}{
+		{name: "same case", testString: "admin", values: "admin,staff", err: nil},
+		{name: "other case", testString: "ADMIN", values: "admin,staff", err: nil},
+		{name: "unicode", testString: "ДИМА", values: "дима", err: nil},
+		{name: "validate error", testString: "guest", values: "admin,staff", err: ErrValidationStrIn},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, checkRule("in_ci:"+tc.values, tc.testString))
+		})
+	}
+	require.Equal(t, ErrValidationStrIn, checkRule("in:admin", "ADMIN"))
+}
+
+func TestValidateStringMatch(t *testing.T) {
+	tests := []struct {
+		tag        string
+		testString string
+		err        error
+	}{
+		{tag: "startswith:'https://'", testString: "https://example.com", err: nil},
+		{tag: `startswith:http\:,https\:`, testString: "http://example.com", err: nil},
+		{tag: "startswith:'https://'", testString: "ftp://example.com", err: ErrValidationStrStartsWith},
+		{tag: "endswith:.com,.org", testString: "example.org", err: nil},
+		{tag: "endswith:.com", testString: "example.net", err: ErrValidationStrEndsWith},
+		{tag: "contains:@", testString: "bob@example.com", err: nil},
+		{tag: "contains:@", testString: "bob", err: ErrValidationStrContains},
+		{tag: "excludes:<,>", testString: "bob", err: nil},
+		{tag: "excludes:<,>", testString: "<script>", err: ErrValidationStrExcludes},
+		{tag: "lowercase", testString: "bob-42", err: nil},
+		{tag: "lowercase", testString: "", err: nil},
+		{tag: "lowercase", testString: "дмитрий", err: nil},
+		{tag: "lowercase", testString: "Bob", err: ErrValidationStrLowercase},
+		{tag: "lowercase", testString: "ǅ", err: ErrValidationStrLowercase},
+		{tag: "uppercase", testString: "BOB-42", err: nil},
+		{tag: "uppercase", testString: "BoB", err: ErrValidationStrUppercase},
+		{tag: "ascii", testString: "bob\t42", err: nil},
+		{tag: "ascii", testString: "Дима", err: ErrValidationStrASCII},
+		{tag: "printable", testString: "Дима 42!", err: nil},
+		{tag: "printable", testString: "bob\n", err: ErrValidationStrPrintable},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag+" "+tc.testString, func(t *testing.T) {
+			require.Equal(t, tc.err, checkRule(tc.tag, tc.testString))
+		})
+	}
+}
+
+func TestStringRuleErrors(t *testing.T) {
+	require.Equal(t, ErrInvalidOperand, checkRule("startswith", "foo"))
+	require.Equal(t, ErrInvalidOperand, checkRule("excludes:", "foo"))
+	require.Equal(t, ErrInvalidOperand, checkRule("lowercase:strict", "foo"))
+	require.Equal(t, ErrUnsupCondition, checkRule("startswith:a", 1))
+}
@@ -1,36 +1,24 @@
 package struct_validator
 
 import (
-	"errors"
 	"reflect"
 )
 
-func validateStructField(tag string, value reflect.Value) error {
-	var verr ValidationErrors
-	var err error
-	ve := ValidationErrors{}
+func structRule(c Condition) (rule, error) {
+	switch c.operator {
+	case "nested":
+		return rule{Condition: c}, nil
+	default:
+		return rule{}, ErrUnsupCondition
+	}
+}
 
-	cond, err := parseConditions(tag)
+// validateStructField validates the fields of the nested struct value, found
+// at path. Their paths are prefixed with path.
+func (w *walker) validateStructField(value reflect.Value, path Path) error {
+	plan, err := w.v.planOf(value.Type())
 	if err != nil {
 		return err
 	}
-
-	for _, c := range cond {
-		switch c.operator {
-		case "nested":
-			err = Validate(value.Interface())
-		default:
-			return ErrUnsupCondition
-		}
-		if err != nil {
-			if !errors.As(err, &verr) {
-				return err
-			}
-			ve = append(ve, verr...)
-		}
-	}
-	if len(ve) != 0 {
-		return ve
-	}
-	return nil
+	return w.validateStruct(value, plan, path)
 }
@@ -2,7 +2,6 @@ package struct_validator
 
 import (
 	"errors"
-	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -24,7 +23,7 @@ func TestValidateStructField(t *testing.T) {
 				Name: "Validation Error",
 			},
 			err: ValidationErrors{
-				ValidationError{Field: "Name", Err: ErrValidationStrLen},
+				fieldError(ErrValidationStrLen, "Struct", "Name"),
 			},
 		},
 		{
@@ -52,7 +51,7 @@ func TestValidateStructField(t *testing.T) {
 	var verr ValidationErrors
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateStructField(tc.tag, reflect.ValueOf(tc.input))
+			err := validateTagged("Struct", tc.tag, tc.input)
 			if errors.As(err, &verr) {
 				require.Equal(t, tc.err, verr)
 				return
@@ -61,3 +60,168 @@ func TestValidateStructField(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNestedPaths(t *testing.T) {
+	type (
+		Item struct {
+			SKU string `validate:"len:8"`
+		}
+		Order struct {
+			ID    int    `validate:"min:1"`
+			Items []Item `validate:"nested"`
+		}
+		Cart struct {
+			User   User    `validate:"nested"`
+			Orders []Order `validate:"nested"`
+		}
+	)
+
+	cart := Cart{
+		User: User{
+			ID:     "7f0e3265-ca96-4b33-8858-fef9696cc71b",
+			Age:    17,
+			Email:  "somemail@gmail.com",
+			Role:   "admin",
+			Phones: []string{"12345678901"},
+		},
+		Orders: []Order{
+			{ID: 1, Items: []Item{{SKU: "ABCD1234"}}},
+			{ID: 0},
+			{ID: 3, Items: []Item{{SKU: "ABCD1234"}, {SKU: "ABC"}}},
+		},
+	}
+
+	err := Validate(cart)
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationIntMin, "User", "Age"),
+		fieldError(ErrValidationIntMin, "Orders", 1, "ID"),
+		fieldError(ErrValidationStrLen, "Orders", 2, "Items", 1, "SKU"),
+	}, err)
+
+	var verr ValidationErrors
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, "Orders[2].Items[1].SKU", verr[2].Field)
+	require.Equal(t, Path{{Name: "Orders"}, {Index: 2}, {Name: "Items"}, {Index: 1}, {Name: "SKU"}}, verr[2].Path)
+}
+
+type (
+	Audit struct {
+		CreatedBy string `validate:"required"`
+		Name      string `validate:"len:3"`
+	}
+	Contact struct {
+		Email string `validate:"email"`
+		Phone string `validate:"omitempty|e164"`
+	}
+	Billing struct {
+		ID  int `validate:"min:1"`
+		Max int `validate:"gtefield:ID"`
+	}
+	Shipping struct {
+		ID int `validate:"min:1"`
+	}
+	meta struct {
+		Version int `validate:"min:1"`
+	}
+	Node struct {
+		*Node
+		Depth int `validate:"max:1"`
+	}
+	Window struct {
+		Opens, Closes int
+	}
+)
+
+func (w Window) Validate() error {
+	if w.Closes <= w.Opens {
+		return ValidationErrors{newValidationError(Path{{Name: "Closes"}}, errPeriod)}
+	}
+	return nil
+}
+
+func TestValidateEmbedded(t *testing.T) {
+	type (
+		Customer struct {
+			Audit
+			*Contact
+			Name string `validate:"minlen:2"`
+		}
+		Account struct {
+			*Contact `validate:"required"`
+			Billing
+			Shipping
+			meta
+		}
+		Private struct {
+			Audit `validate:"-"`
+			Code  string `validate:"-"`
+		}
+		Shop struct {
+			Window
+			Name string `validate:"required"`
+		}
+	)
+
+	tests := []struct {
+		name        string
+		in          interface{}
+		expectedErr error
+	}{
+		{
+			name: "promoted and shadowed fields",
+			in:   Customer{Audit: Audit{Name: "ab"}, Contact: &Contact{Email: "bob", Phone: "1"}, Name: "Bob"},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "CreatedBy"),
+				fieldError(ErrValidationStrLen, "Audit", "Name"),
+				fieldError(ErrValidationEmail, "Email"),
+				fieldError(ErrValidationE164, "Phone"),
+			},
+		},
+		{
+			name:        "nil embedded pointer",
+			in:          &Customer{Audit: Audit{CreatedBy: "admin", Name: "abc"}, Name: "B"},
+			expectedErr: ValidationErrors{fieldError(ErrValidationStrMinLen, "Name")},
+		},
+		{
+			name: "ambiguous fields and unexported embedded struct",
+			in:   Account{Contact: &Contact{Email: "bob@example.com"}, Billing: Billing{Max: -1}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationIntMin, "Billing", "ID"),
+				fieldError(&CrossFieldError{Field: "ID", Err: ErrValidationGteField}, "Max"),
+				fieldError(ErrValidationIntMin, "Shipping", "ID"),
+				fieldError(ErrValidationIntMin, "Version"),
+			},
+		},
+		{
+			name: "required embedded pointer",
+			in:   Account{Billing: Billing{ID: 1, Max: 1}, Shipping: Shipping{ID: 1}, meta: meta{Version: 1}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "Contact"),
+			},
+		},
+		{
+			name:        "opted out",
+			in:          Private{},
+			expectedErr: nil,
+		},
+		{
+			name:        "cycle",
+			in:          Node{Node: &Node{Depth: 2}, Depth: 1},
+			expectedErr: nil,
+		},
+		{
+			name: "promoted hook",
+			in:   Shop{Window: Window{Opens: 9, Closes: 9}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "Name"),
+				fieldError(errPeriod, "Closes"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package struct_validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The validate tag grammar:
+//
+//	tag       = chain { or chain }
+//	chain     = term { and term }
+//	term      = [ "!" ] ( "(" tag ")" | condition )
+//	condition = operator [ operand-sep param { list param } ]
+//	param     = quoted | { char | escape }
+//
+// "and" binds tighter than "or", so a|b||c is (a|b)||c. A "!" negates the
+// term it starts. Presence operators, e.g. "omitempty", apply to the whole
+// field and can't be in an alternative or a group; an empty string is allowed
+// by an alternative like len:0||len:36 instead.
+//
+// A backslash escapes a separator, a quote, a parenthesis or itself; before
+// any other char it's kept, so regexps like `^\d+$` need no escaping. Balanced
+// parentheses inside a param are kept as well, along with the separators
+// between them. A param starting with a quote runs to the closing quote and
+// is taken literally, a quote inside it is doubled:
+//
+//	in:'a,b','it''s'
+const (
+	quote      = "'"
+	negation   = "!"
+	openGroup  = "("
+	closeGroup = ")"
+)
+
+// syntax holds the separators of the validate tag mini-language.
+type syntax struct {
+	operand string // between an operator and its operand
+	list    string // between the values of a list operand
+	and     string // between conditions
+	or      string // between alternatives
+}
+
+var defaultSyntax = syntax{operand: splitSymbol, list: inSplitSymbol, and: andSymbol, or: orSymbol}
+
+// check reports separators the parser can't tell apart: equal ones, one
+// starting another, except for "and" starting "or" as "or" is always tried
+// first, and ones holding a char the grammar reserves.
+func (s syntax) check() error {
+	seps := []struct{ name, sep string }{{"operand", s.operand}, {"list", s.list}, {"and", s.and}, {"or", s.or}}
+	for i, a := range seps {
+		if strings.ContainsAny(a.sep, negation+openGroup+closeGroup+quote+`\`) {
+			return fmt.Errorf("%w: %s separator %q holds a reserved char", ErrSeparator, a.name, a.sep)
+		}
+		for _, b := range seps[i+1:] {
+			if a.name == "and" && b.name == "or" && a.sep != b.sep && strings.HasPrefix(b.sep, a.sep) {
+				continue
+			}
+			if strings.HasPrefix(a.sep, b.sep) || strings.HasPrefix(b.sep, a.sep) {
+				return fmt.Errorf("%w: %s separator %q and %s separator %q", ErrSeparator, a.name, a.sep, b.name, b.sep)
+			}
+		}
+	}
+	return nil
+}
+
+// TagError is a malformed validate tag, it wraps ErrValidationFormat.
+type TagError struct {
+	Field string
+	Tag   string
+	Pos   int // byte offset in Tag
+	Msg   string
+}
+
+func (e *TagError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invalid validation tag %q at position %d: %s", e.Tag, e.Pos, e.Msg)
+	}
+	return fmt.Sprintf("invalid validation tag %q of field %s at position %d: %s", e.Tag, e.Field, e.Pos, e.Msg)
+}
+
+func (e *TagError) Unwrap() error {
+	return ErrValidationFormat
+}
+
+// parseConditions parses tag into the conditions that must all be satisfied.
+// Alternatives are kept in a single Condition with anyOf set.
+func parseConditions(tag string, s syntax) ([]Condition, error) {
+	p := tagParser{tag: tag, s: s}
+	alts, err := p.alternatives()
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", closeGroup)
+	}
+	for _, at := range p.presence {
+		if at.depth > 0 || len(alts) > 1 {
+			p.pos = at.pos
+			return nil, p.errorf("%q applies to the whole field, it can't be in an alternative or a group", at.operator)
+		}
+	}
+	if len(alts) == 1 {
+		return alts[0], nil
+	}
+	return []Condition{{anyOf: alts}}, nil
+}
+
+type tagParser struct {
+	tag      string
+	pos      int
+	s        syntax
+	depth    int          // of the groups the parser is in
+	presence []presenceAt // presence operators met so far
+}
+
+// presenceAt is a presence operator found in a tag.
+type presenceAt struct {
+	operator string
+	pos      int
+	depth    int
+}
+
+func (p *tagParser) alternatives() ([][]Condition, error) {
+	alts := [][]Condition{}
+	for {
+		chain, err := p.chain()
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, chain)
+		if !p.consume(p.s.or) {
+			return alts, nil
+		}
+	}
+}
+
+func (p *tagParser) chain() ([]Condition, error) {
+	cond := []Condition{}
+	for {
+		negated := p.consume(negation)
+		if p.consume(openGroup) {
+			alts, err := p.group()
+			if err != nil {
+				return nil, err
+			}
+			if len(alts) == 1 && !negated {
+				cond = append(cond, alts[0]...)
+			} else {
+				cond = append(cond, Condition{anyOf: alts, negated: negated})
+			}
+		} else {
+			c, err := p.condition()
+			if err != nil {
+				return nil, err
+			}
+			c.negated = negated
+			cond = append(cond, c)
+		}
+		if p.at(p.s.or) || !p.consume(p.s.and) {
+			return cond, nil
+		}
+	}
+}
+
+// group parses the alternatives of a group which opening parenthesis is
+// already consumed.
+func (p *tagParser) group() ([][]Condition, error) {
+	start := p.pos - len(openGroup)
+	p.depth++
+	alts, err := p.alternatives()
+	p.depth--
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(closeGroup) {
+		p.pos = start
+		return nil, p.errorf("unclosed %q", openGroup)
+	}
+	if !p.eof() && !p.atSeparator() && !p.atGroupEnd() {
+		return nil, p.errorf("unexpected %q after %q", p.tag[p.pos:p.pos+1], closeGroup)
+	}
+	return alts, nil
+}
+
+func (p *tagParser) condition() (Condition, error) {
+	start := p.pos
+	for !p.eof() && !p.at(p.s.operand) && !p.atSeparator() && !p.atGroupEnd() {
+		p.pos++
+	}
+	c := Condition{operator: p.tag[start:p.pos]}
+	if c.operator == "" {
+		return c, p.errorf("missing operator")
+	}
+	if presence[c.operator] {
+		p.presence = append(p.presence, presenceAt{operator: c.operator, pos: start, depth: p.depth})
+	}
+	if !p.consume(p.s.operand) {
+		c.params = []string{""}
+		return c, nil
+	}
+
+	var operand strings.Builder
+	for {
+		param, err := p.param()
+		if err != nil {
+			return c, err
+		}
+		c.params = append(c.params, param)
+		operand.WriteString(param)
+		if !p.consume(p.s.list) {
+			break
+		}
+		operand.WriteString(p.s.list)
+	}
+	c.operand = operand.String()
+	return c, nil
+}
+
+func (p *tagParser) param() (string, error) {
+	if p.at(quote) {
+		return p.quoted()
+	}
+
+	var param strings.Builder
+	parens, opened := 0, 0 // open parens and the position of the outermost
+	for !p.eof() && (parens > 0 || !p.atSeparator() && !p.at(p.s.list)) {
+		switch {
+		case p.at(p.s.operand) && parens == 0:
+			return "", p.errorf("unexpected %q, escape or quote the operand", p.s.operand)
+		case p.at(closeGroup) && parens == 0 && p.depth > 0:
+			return param.String(), nil
+		case p.at(openGroup):
+			if parens == 0 {
+				opened = p.pos
+			}
+			parens++
+		case p.at(closeGroup) && parens > 0:
+			parens--
+		case p.consume(`\`):
+			if esc := p.escaped(); esc != "" {
+				param.WriteString(esc)
+				p.pos += len(esc)
+				continue
+			}
+			param.WriteByte('\\')
+			continue
+		}
+		param.WriteByte(p.tag[p.pos])
+		p.pos++
+	}
+	if parens > 0 {
+		p.pos = opened
+		return "", p.errorf("unclosed %q", openGroup)
+	}
+	return param.String(), nil
+}
+
+func (p *tagParser) quoted() (string, error) {
+	start := p.pos
+	p.pos += len(quote)
+
+	var param strings.Builder
+	for {
+		if p.eof() {
+			p.pos = start
+			return "", p.errorf("unterminated quote")
+		}
+		if p.consume(quote) {
+			if !p.consume(quote) {
+				break
+			}
+			param.WriteString(quote)
+			continue
+		}
+		param.WriteByte(p.tag[p.pos])
+		p.pos++
+	}
+
+	if !p.eof() && !p.atSeparator() && !p.at(p.s.list) && !p.atGroupEnd() {
+		return "", p.errorf("unexpected %q after a quoted param", p.tag[p.pos:p.pos+1])
+	}
+	return param.String(), nil
+}
+
+// escaped returns the separator or char escaped at the current position.
+func (p *tagParser) escaped() string {
+	for _, esc := range p.s.specials() {
+		if p.at(esc) {
+			return esc
+		}
+	}
+	return ""
+}
+
+func (p *tagParser) eof() bool {
+	return p.pos >= len(p.tag)
+}
+
+func (p *tagParser) at(s string) bool {
+	return strings.HasPrefix(p.tag[p.pos:], s)
+}
+
+// atSeparator reports whether a condition ends at the current position.
+func (p *tagParser) atSeparator() bool {
+	return p.at(p.s.or) || p.at(p.s.and)
+}
+
+func (p *tagParser) atGroupEnd() bool {
+	return p.depth > 0 && p.at(closeGroup)
+}
+
+func (p *tagParser) consume(s string) bool {
+	if p.at(s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *tagParser) errorf(format string, args ...interface{}) error {
+	return &TagError{Tag: p.tag, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// specials are the strings escaped by a backslash, longest first.
+func (s syntax) specials() []string {
+	specials := []string{s.or, s.and, s.list, s.operand, quote, openGroup, closeGroup, `\`}
+	sort.SliceStable(specials, func(i, j int) bool { return len(specials[i]) > len(specials[j]) })
+	return specials
+}
+
+// format renders c in the syntax s, escaping its params so that parsing the
+// result gives c back.
+func (c Condition) format(s syntax) string {
+	var cond strings.Builder
+	if c.negated {
+		cond.WriteString(negation)
+	}
+	if c.anyOf != nil {
+		alts := make([]string, len(c.anyOf))
+		for i, chain := range c.anyOf {
+			alts[i] = formatConditions(chain, s)
+		}
+		return cond.String() + openGroup + strings.Join(alts, s.or) + closeGroup
+	}
+	cond.WriteString(c.operator)
+	if c.operand == "" {
+		return cond.String()
+	}
+
+	cond.WriteString(s.operand)
+	specials := s.specials()
+	for i, param := range c.params {
+		if i > 0 {
+			cond.WriteString(s.list)
+		}
+		for len(param) > 0 {
+			n := 1
+			for _, esc := range specials {
+				if strings.HasPrefix(param, esc) {
+					cond.WriteByte('\\')
+					n = len(esc)
+					break
+				}
+			}
+			cond.WriteString(param[:n])
+			param = param[n:]
+		}
+	}
+	return cond.String()
+}
+
+func formatConditions(cond []Condition, s syntax) string {
+	formatted := make([]string, len(cond))
+	for i, c := range cond {
+		formatted[i] = c.format(s)
+	}
+	return strings.Join(formatted, s.and)
+}
+
+func (c Condition) String() string {
+	return c.format(defaultSyntax)
+}
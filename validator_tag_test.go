@@ -0,0 +1,305 @@
+package struct_validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConditions(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		cond []Condition
+		err  error
+	}{
+		{
+			name: "length tag",
+			tag:  "len:5",
+			cond: []Condition{cond("len", "5")},
+			err:  nil,
+		},
+		{
+			name: "regexp tag",
+			tag:  "regexp:regarg",
+			cond: []Condition{cond("regexp", "regarg")},
+			err:  nil,
+		},
+		{
+			name: "in string tag",
+			tag:  "in:first,second",
+			cond: []Condition{cond("in", "first,second")},
+			err:  nil,
+		},
+		{
+			name: "min tag",
+			tag:  "min:10",
+			cond: []Condition{cond("min", "10")},
+			err:  nil,
+		},
+		{
+			name: "max tag",
+			tag:  "max:20",
+			cond: []Condition{cond("max", "20")},
+			err:  nil,
+		},
+		{
+			name: "in int tag",
+			tag:  "in:15,16",
+			cond: []Condition{cond("in", "15,16")},
+			err:  nil,
+		},
+		{
+			name: "multi string tag",
+			tag:  "len:5|regexp:regarg|in:first",
+			cond: []Condition{cond("len", "5"), cond("regexp", "regarg"), cond("in", "first")},
+			err:  nil,
+		},
+		{
+			name: "multi int tag",
+			tag:  "min:5|max:20|in:15",
+			cond: []Condition{cond("min", "5"), cond("max", "20"), cond("in", "15")},
+			err:  nil,
+		},
+		{
+			name: "without operand",
+			tag:  "nested",
+			cond: []Condition{cond("nested", "")},
+			err:  nil,
+		},
+		{
+			name: "validation format error",
+			tag:  "validation:format:error",
+			cond: nil,
+			err:  ErrValidationFormat,
+		},
+		{
+			name: "escaped operand separator",
+			tag:  `regexp:^\d{2}\:\d{2}$`,
+			cond: []Condition{{operator: "regexp", operand: `^\d{2}:\d{2}$`, params: []string{`^\d{2}:\d{2}$`}}},
+			err:  nil,
+		},
+		{
+			name: "quoted operand",
+			tag:  `regexp:'^(\d+|-):\w$'|len:5`,
+			cond: []Condition{
+				{operator: "regexp", operand: `^(\d+|-):\w$`, params: []string{`^(\d+|-):\w$`}},
+				cond("len", "5"),
+			},
+			err: nil,
+		},
+		{
+			name: "escaped list separator",
+			tag:  `in:a\,b,c\|d,e\\`,
+			cond: []Condition{{operator: "in", operand: `a,b,c|d,e\`, params: []string{"a,b", "c|d", `e\`}}},
+			err:  nil,
+		},
+		{
+			name: "quoted params",
+			tag:  `in:'a,b','it''s',c`,
+			cond: []Condition{{operator: "in", operand: "a,b,it's,c", params: []string{"a,b", "it's", "c"}}},
+			err:  nil,
+		},
+		{
+			name: "quote inside param",
+			tag:  `in:it's,x`,
+			cond: []Condition{{operator: "in", operand: "it's,x", params: []string{"it's", "x"}}},
+			err:  nil,
+		},
+		{
+			name: "alternatives",
+			tag:  "len:0||len:36",
+			cond: []Condition{{anyOf: [][]Condition{{cond("len", "0")}, {cond("len", "36")}}}},
+			err:  nil,
+		},
+		{
+			name: "and binds tighter than or",
+			tag:  "min:1|max:5||in:10",
+			cond: []Condition{{anyOf: [][]Condition{{cond("min", "1"), cond("max", "5")}, {cond("in", "10")}}}},
+			err:  nil,
+		},
+		{
+			name: "group",
+			tag:  "len:3|(in:foo,bar||regexp:^x)|nested",
+			cond: []Condition{
+				cond("len", "3"),
+				{anyOf: [][]Condition{{cond("in", "foo,bar")}, {cond("regexp", "^x")}}},
+				cond("nested", ""),
+			},
+			err: nil,
+		},
+		{
+			name: "single alternative group",
+			tag:  "(min:1|max:5)|in:3",
+			cond: []Condition{cond("min", "1"), cond("max", "5"), cond("in", "3")},
+			err:  nil,
+		},
+		{
+			name: "nested groups",
+			tag:  "(len:1||(len:2|in:ab||in:xyz))",
+			cond: []Condition{{anyOf: [][]Condition{
+				{cond("len", "1")},
+				{{anyOf: [][]Condition{{cond("len", "2"), cond("in", "ab")}, {cond("in", "xyz")}}}},
+			}}},
+			err: nil,
+		},
+		{
+			name: "parentheses in a param",
+			tag:  "(regexp:^(a|b)$||regexp:^\\(c\\)$)",
+			cond: []Condition{{anyOf: [][]Condition{{cond("regexp", "^(a|b)$")}, {cond("regexp", "^(c)$")}}}},
+			err:  nil,
+		},
+		{
+			name: "closing parenthesis outside a group",
+			tag:  "regexp:a)",
+			cond: []Condition{cond("regexp", "a)")},
+			err:  nil,
+		},
+		{
+			name: "empty params",
+			tag:  "in:,|in:",
+			cond: []Condition{{operator: "in", operand: ",", params: []string{"", ""}}, cond("in", "")},
+			err:  nil,
+		},
+		{
+			name: "negated condition",
+			tag:  "!in:root,admin|len:5",
+			cond: []Condition{{operator: "in", operand: "root,admin", params: []string{"root", "admin"}, negated: true}, cond("len", "5")},
+			err:  nil,
+		},
+		{
+			name: "negated group",
+			tag:  "!(len:3|in:foo)",
+			cond: []Condition{{anyOf: [][]Condition{{cond("len", "3"), cond("in", "foo")}}, negated: true}},
+			err:  nil,
+		},
+		{
+			name: "exclamation mark in a param",
+			tag:  "in:!a,b!",
+			cond: []Condition{cond("in", "!a,b!")},
+			err:  nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := parseConditions(tc.tag, defaultSyntax)
+			require.Equal(t, tc.cond, c)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestParseConditionsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		pos  int
+		msg  string
+	}{
+		{name: "empty tag", tag: "", pos: 0, msg: "missing operator"},
+		{name: "trailing separator", tag: "len:5|", pos: 6, msg: "missing operator"},
+		{name: "missing operator", tag: "len:5|:3", pos: 6, msg: "missing operator"},
+		{name: "second operand separator", tag: "min:1|regexp:a:b", pos: 14, msg: `unexpected ":", escape or quote the operand`},
+		{name: "unterminated quote", tag: "in:a,'b,c", pos: 5, msg: "unterminated quote"},
+		{name: "text after quote", tag: "in:'a'b", pos: 6, msg: `unexpected "b" after a quoted param`},
+		{name: "unclosed group", tag: "len:1|(in:a||in:b", pos: 6, msg: `unclosed "("`},
+		{name: "unclosed param paren", tag: "in:a,(b|required", pos: 5, msg: `unclosed "("`},
+		{name: "unclosed nested param paren", tag: "contains:((a)|len:3", pos: 9, msg: `unclosed "("`},
+		{name: "unclosed param paren before a separator", tag: "contains:(|len:3", pos: 9, msg: `unclosed "("`},
+		{name: "param paren closing a group", tag: "(contains:(||len:3)", pos: 0, msg: `unclosed "("`},
+		{name: "unopened group", tag: "(in:a||in:b))", pos: 12, msg: `unexpected ")" after ")"`},
+		{name: "text after group", tag: "(in:a||in:b)x", pos: 12, msg: `unexpected "x" after ")"`},
+		{name: "empty group", tag: "len:1|()", pos: 7, msg: "missing operator"},
+		{name: "empty alternative", tag: "in:a||", pos: 6, msg: "missing operator"},
+		{name: "negation only", tag: "len:1|!", pos: 7, msg: "missing operator"},
+		{name: "presence in an alternative", tag: "omitempty||len:36", pos: 0, msg: `"omitempty" applies to the whole field, it can't be in an alternative or a group`},
+		{name: "presence in a group", tag: "len:1|(omitempty||len:36)", pos: 7, msg: `"omitempty" applies to the whole field, it can't be in an alternative or a group`},
+		{name: "presence in a single group", tag: "(required|len:36)", pos: 1, msg: `"required" applies to the whole field, it can't be in an alternative or a group`},
+		{name: "negated presence in an alternative", tag: "len:0||!required_with:A", pos: 8, msg: `"required_with" applies to the whole field, it can't be in an alternative or a group`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseConditions(tc.tag, defaultSyntax)
+			require.ErrorIs(t, err, ErrValidationFormat)
+
+			var terr *TagError
+			require.True(t, errors.As(err, &terr))
+			require.Equal(t, tc.tag, terr.Tag)
+			require.Equal(t, tc.pos, terr.Pos)
+			require.Equal(t, tc.msg, terr.Msg)
+		})
+	}
+
+	err := Validate(struct {
+		Time string `validate:"regexp:^\\d{2}:\\d{2}$"`
+	}{})
+	require.EqualError(t, err, `invalid validation tag "regexp:^\\d{2}:\\d{2}$" of field Time at position 13: `+
+		`unexpected ":", escape or quote the operand`)
+}
+
+func TestConditionRoundTrip(t *testing.T) {
+	tests := []struct {
+		tag    string
+		syntax syntax
+		format string
+	}{
+		{tag: "len:5", syntax: defaultSyntax, format: "len:5"},
+		{tag: "nested", syntax: defaultSyntax, format: "nested"},
+		{tag: `regexp:^\w+@\w+\.\w+$`, syntax: defaultSyntax, format: `regexp:^\\w+@\\w+\\.\\w+$`},
+		{tag: `regexp:'^\d{2}:\d{2}$'`, syntax: defaultSyntax, format: `regexp:^\\d{2}\:\\d{2}$`},
+		{tag: `in:'a,b','it''s',c\|d`, syntax: defaultSyntax, format: `in:a\,b,it\'s,c\|d`},
+		{tag: `in:\'quoted\'`, syntax: defaultSyntax, format: `in:\'quoted\'`},
+		{tag: "in:a,,b", syntax: defaultSyntax, format: "in:a,,b"},
+		{tag: "in:a||len:3|min:1", syntax: defaultSyntax, format: "(in:a||len:3|min:1)"},
+		{tag: "(in:a\\(||(len:3||min:1))|max:5", syntax: defaultSyntax, format: "(in:a\\(||(len:3||min:1))"},
+		{tag: "!regexp:^a!$|!(in:a||!in:b)", syntax: defaultSyntax, format: "!regexp:^a!$"},
+		{
+			tag:    `in=a/b\/c;regexp=x\=y`,
+			syntax: syntax{operand: "=", list: "/", and: ";", or: "/or/"},
+			format: `in=a/b\/c`,
+		},
+		{
+			tag:    `in::a,,b\,,c&&len::3`,
+			syntax: syntax{operand: "::", list: ",,", and: "&&", or: "||"},
+			format: `in::a,,b\,,c`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag, func(t *testing.T) {
+			cond, err := parseConditions(tc.tag, tc.syntax)
+			require.NoError(t, err)
+			require.Equal(t, tc.format, cond[0].format(tc.syntax))
+
+			formatted := make([]string, len(cond))
+			for i, c := range cond {
+				formatted[i] = c.format(tc.syntax)
+			}
+			again, err := parseConditions(strings.Join(formatted, tc.syntax.and), tc.syntax)
+			require.NoError(t, err)
+			require.Equal(t, cond, again)
+		})
+	}
+}
+
+func TestValidateEscapedTags(t *testing.T) {
+	type Schedule struct {
+		Start string `validate:"regexp:'^\\d{2}:\\d{2}$'"`
+		End   string `validate:"regexp:^\\d{2}\\:\\d{2}$"`
+		Kind  string `validate:"regexp:'^(daily|weekly)$'"`
+		Label string `validate:"in:'a,b',c\\,d"`
+	}
+
+	require.NoError(t, Validate(Schedule{Start: "09:00", End: "18:30", Kind: "daily", Label: "a,b"}))
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationStrRegexp, "Start"),
+		fieldError(ErrValidationStrRegexp, "End"),
+		fieldError(ErrValidationStrRegexp, "Kind"),
+		fieldError(ErrValidationStrIn, "Label"),
+	}, Validate(Schedule{Start: "9:00", End: "18-30", Kind: "monthly", Label: "a"}))
+	require.NoError(t, Validate(Schedule{Start: "00:00", End: "00:00", Kind: "weekly", Label: "c,d"}))
+}
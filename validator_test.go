@@ -1,10 +1,12 @@
 package struct_validator
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,6 +41,13 @@ type (
 		Body string `json:"omitempty"`
 	}
 
+	Listing struct {
+		ID    int64   `validate:"min:1"`
+		Port  uint16  `validate:"min:1024"`
+		Price float64 `validate:"min:0.01|max:1e6"`
+		Stock []uint8 `validate:"max:100"`
+	}
+
 	Nested struct {
 		User     User `validate:"nested"`
 		Intfield int  `validate:"in:200,404"`
@@ -74,11 +83,11 @@ func TestValidate(t *testing.T) {
 				meta:   []byte{12},
 			},
 			expectedErr: ValidationErrors{
-				ValidationError{Field: "ID", Err: ErrValidationStrLen},
-				ValidationError{Field: "Age", Err: ErrValidationIntMin},
-				ValidationError{Field: "Email", Err: ErrValidationStrRegexp},
-				ValidationError{Field: "Role", Err: ErrValidationStrIn},
-				ValidationError{Field: "Phones", Err: ErrValidationStrLen},
+				fieldError(ErrValidationStrLen, "ID"),
+				fieldError(ErrValidationIntMin, "Age"),
+				fieldError(ErrValidationStrRegexp, "Email"),
+				fieldError(ErrValidationStrIn, "Role"),
+				fieldError(ErrValidationStrLen, "Phones", 0),
 			},
 		},
 		{
@@ -92,7 +101,7 @@ func TestValidate(t *testing.T) {
 				Version: "release",
 			},
 			expectedErr: ValidationErrors{
-				ValidationError{Field: "Version", Err: ErrValidationStrLen},
+				fieldError(ErrValidationStrLen, "Version"),
 			},
 		},
 		{
@@ -109,7 +118,30 @@ func TestValidate(t *testing.T) {
 				Body: "body",
 			},
 			expectedErr: ValidationErrors{
-				ValidationError{Field: "Code", Err: ErrValidationIntIn},
+				fieldError(ErrValidationIntIn, "Code"),
+			},
+		},
+		{
+			in: Listing{
+				ID:    9007199254740993,
+				Port:  8080,
+				Price: 19.99,
+				Stock: []uint8{0, 100},
+			},
+			expectedErr: nil,
+		},
+		{
+			in: Listing{
+				ID:    0,
+				Port:  80,
+				Price: 0,
+				Stock: []uint8{101},
+			},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationIntMin, "ID"),
+				fieldError(ErrValidationIntMin, "Port"),
+				fieldError(ErrValidationFloatMin, "Price"),
+				fieldError(ErrValidationIntMax, "Stock", 0),
 			},
 		},
 		{
@@ -129,9 +161,9 @@ func TestValidate(t *testing.T) {
 				},
 			},
 			expectedErr: ValidationErrors{
-				ValidationError{Field: "Email", Err: ErrValidationStrRegexp},
-				ValidationError{Field: "Intfield", Err: ErrValidationIntIn},
-				ValidationError{Field: "Version", Err: ErrValidationStrLen},
+				fieldError(ErrValidationStrRegexp, "User", "Email"),
+				fieldError(ErrValidationIntIn, "Intfield"),
+				fieldError(ErrValidationStrLen, "App", "Version"),
 			},
 		},
 	}
@@ -145,235 +177,363 @@ func TestValidate(t *testing.T) {
 	}
 }
 
-func TestValidateField(t *testing.T) {
+func TestValidatePointers(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(i int) *int { return &i }
+
+	type (
+		Pointers struct {
+			Name   *string   `validate:"len:4"`
+			Age    *int      `validate:"min:18"`
+			App    *App      `validate:"nested"`
+			Token  *string   `validate:"required|len:5"`
+			Phones []*string `validate:"len:3"`
+		}
+
+		Node struct {
+			Value int   `validate:"max:10"`
+			Next  *Node `validate:"nested"`
+		}
+	)
+
 	tests := []struct {
-		name  string
-		field Field
-		err   error
+		name        string
+		in          interface{}
+		expectedErr error
 	}{
 		{
-			name: "string",
-			field: Field{
-				name:  "StrTag",
-				value: reflect.ValueOf("foo"),
-				tag:   "in:foo|regexp:foo|len:3",
-				kind:  reflect.String,
-			},
-			err: nil,
+			name:        "pointer to struct",
+			in:          &App{Version: "debug"},
+			expectedErr: nil,
 		},
 		{
-			name: "string slice",
-			field: Field{
-				name:  "StrTag",
-				value: reflect.ValueOf([]string{"foo", "bar"}),
-				tag:   "in:foo|regexp:foo|len:3",
-				kind:  reflect.Slice,
-			},
-			err: ValidationErrors{
-				ValidationError{Field: "StrTag", Err: ErrValidationStrIn},
-				ValidationError{Field: "StrTag", Err: ErrValidationStrRegexp},
+			name: "pointer to pointer to struct",
+			in:   func() **App { a := &App{Version: "release"}; return &a }(),
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationStrLen, "Version"),
 			},
 		},
 		{
-			name: "string, validation error",
-			field: Field{
-				name:  "StrTag",
-				value: reflect.ValueOf("bar"),
-				tag:   "in:foo|regexp:foo|len:6",
-				kind:  reflect.String,
-			},
-			err: ValidationErrors{
-				ValidationError{Field: "StrTag", Err: ErrValidationStrIn},
-				ValidationError{Field: "StrTag", Err: ErrValidationStrRegexp},
-				ValidationError{Field: "StrTag", Err: ErrValidationStrLen},
-			},
+			name:        "nil pointer",
+			in:          (*App)(nil),
+			expectedErr: ErrNilPointer,
+		},
+		{
+			name:        "nil",
+			in:          nil,
+			expectedErr: ErrType,
 		},
 		{
-			name: "int",
-			field: Field{
-				name:  "IntTag",
-				value: reflect.ValueOf(25),
-				tag:   "in:20,25,30|max:30|min:20",
-				kind:  reflect.Int,
+			name: "pointer fields",
+			in: Pointers{
+				Name:   str("Name"),
+				Age:    num(20),
+				App:    &App{Version: "debug"},
+				Token:  str("token"),
+				Phones: []*string{str("123"), nil, str("456")},
 			},
-			err: nil,
+			expectedErr: nil,
 		},
 		{
-			name: "int slice",
-			field: Field{
-				name:  "IntTag",
-				value: reflect.ValueOf([]int{10, 20}),
-				tag:   "in:5,15|max:16|min:4",
-				kind:  reflect.Slice,
+			name: "pointer fields, validation error",
+			in: &Pointers{
+				Name:   str("Bob"),
+				Age:    num(10),
+				App:    &App{Version: "release"},
+				Token:  str("tok"),
+				Phones: []*string{str("1234"), nil},
 			},
-			err: ValidationErrors{
-				ValidationError{Field: "IntTag", Err: ErrValidationIntIn},
-				ValidationError{Field: "IntTag", Err: ErrValidationIntIn},
-				ValidationError{Field: "IntTag", Err: ErrValidationIntMax},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationStrLen, "Name"),
+				fieldError(ErrValidationIntMin, "Age"),
+				fieldError(ErrValidationStrLen, "App", "Version"),
+				fieldError(ErrValidationStrLen, "Token"),
+				fieldError(ErrValidationStrLen, "Phones", 0),
 			},
 		},
 		{
-			name: "int, validation error",
-			field: Field{
-				name:  "IntTag",
-				value: reflect.ValueOf(50),
-				tag:   "in:20,30|max:30|min:60",
-				kind:  reflect.Int,
-			},
-			err: ValidationErrors{
-				ValidationError{Field: "IntTag", Err: ErrValidationIntIn},
-				ValidationError{Field: "IntTag", Err: ErrValidationIntMax},
-				ValidationError{Field: "IntTag", Err: ErrValidationIntMin},
+			name: "nil fields are skipped unless required",
+			in:   Pointers{},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "Token"),
 			},
 		},
 		{
-			name: "unsupported type",
-			field: Field{
-				name:  "BoolTag",
-				value: reflect.ValueOf(true),
-				tag:   "validate:tag",
-				kind:  reflect.Bool,
+			name: "recursive type",
+			in:   &Node{Value: 1, Next: &Node{Value: 2, Next: &Node{Value: 30}}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationIntMax, "Next", "Next", "Value"),
 			},
-			err: ErrUnsupType,
 		},
 	}
 
-	var verr ValidationErrors
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateField(tc.field)
-			if errors.As(err, &verr) {
-				require.Equal(t, tc.err, verr)
-				return
-			}
-			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
 		})
 	}
 }
 
-func TestParseFieldByTag(t *testing.T) {
-	testStruct := struct {
-		StrTag     string `validate:"in:foo|regexp:foo|len:3"`
-		IntTag     int    `validate:"in:10,20|max:20|min:30"`
-		unexpField int    `validate:"in:10,20|max:20|min:30"`
-		JSONTag    string `json:"id"`
-		NoTag      string
-	}{"string", 0, 0, "json", "notag"}
+func TestValidateRequired(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	type (
+		Required struct {
+			Name    string            `validate:"required"`
+			Age     int               `validate:"required|min:18"`
+			Score   float64           `validate:"required"`
+			Token   *string           `validate:"required"`
+			Phones  []string          `validate:"required|dive|len:3"`
+			Labels  map[string]string `validate:"required"`
+			App     App               `validate:"required|nested"`
+			Friends []*string         `validate:"dive|required"`
+			Digest  [2]byte           `validate:"required"`
+		}
+
+		Optional struct {
+			Email  string   `validate:"omitempty|regexp:^\\w+@\\w+\\.\\w+$"`
+			Age    int      `validate:"omitempty|min:18"`
+			Token  *string  `validate:"omitempty|len:5"`
+			Phones []string `validate:"omitempty|len:3"`
+			App    App      `validate:"omitempty|nested"`
+			Tags   []string `validate:"dive|omitempty|len:3"`
+		}
+	)
 
 	tests := []struct {
-		name  string
-		st    interface{}
-		tag   string
-		field []Field
-		err   error
+		name        string
+		in          interface{}
+		expectedErr error
 	}{
 		{
-			name: "common case",
-			st:   testStruct,
-			tag:  validationTag,
-			field: []Field{
-				{"StrTag", reflect.ValueOf(testStruct.StrTag), "in:foo|regexp:foo|len:3", reflect.String},
-				{"IntTag", reflect.ValueOf(testStruct.IntTag), "in:10,20|max:20|min:30", reflect.Int},
+			name: "required values",
+			in: Required{
+				Name:    "Bob",
+				Age:     20,
+				Score:   0.5,
+				Token:   str(""),
+				Phones:  []string{"123"},
+				Labels:  map[string]string{"env": "prod"},
+				App:     App{Version: "debug"},
+				Friends: []*string{str("Alice")},
+				Digest:  [2]byte{0, 1},
 			},
-			err: nil,
+			expectedErr: nil,
 		},
 		{
-			name:  "type error",
-			st:    "string",
-			tag:   validationTag,
-			field: nil,
-			err:   ErrType,
+			name: "missing values",
+			in:   Required{Phones: []string{}, Labels: map[string]string{}, Friends: []*string{str("Alice"), nil}},
+			expectedErr: ValidationErrors{
+				fieldError(ErrRequired, "Name"),
+				fieldError(ErrRequired, "Age"),
+				fieldError(ErrRequired, "Score"),
+				fieldError(ErrRequired, "Token"),
+				fieldError(ErrRequired, "Phones"),
+				fieldError(ErrRequired, "Labels"),
+				fieldError(ErrRequired, "App"),
+				fieldError(ErrRequired, "Friends", 1),
+				fieldError(ErrRequired, "Digest"),
+			},
+		},
+		{
+			name:        "empty values are skipped",
+			in:          Optional{Tags: []string{"foo", "", "bar"}},
+			expectedErr: nil,
+		},
+		{
+			name: "present values are validated",
+			in: Optional{
+				Email:  "bob",
+				Age:    10,
+				Token:  str(""),
+				Phones: []string{"1234"},
+				App:    App{Version: "release"},
+				Tags:   []string{"foo", "", "ba"},
+			},
+			expectedErr: ValidationErrors{
+				fieldError(ErrValidationStrRegexp, "Email"),
+				fieldError(ErrValidationIntMin, "Age"),
+				fieldError(ErrValidationStrLen, "Token"),
+				fieldError(ErrValidationStrLen, "Phones", 0),
+				fieldError(ErrValidationStrLen, "App", "Version"),
+				fieldError(ErrValidationStrLen, "Tags", 2),
+			},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			fields, err := parseFieldByTag(tc.st, tc.tag)
-			require.Equal(t, tc.err, err)
-			for i, f := range fields {
-				require.Equal(t, tc.field[i].name, f.name)
-				require.Equal(t, tc.field[i].value.Interface(), f.value.Interface())
-				require.Equal(t, tc.field[i].tag, f.tag)
-				require.Equal(t, tc.field[i].kind, f.kind)
-			}
+			require.Equal(t, tc.expectedErr, Validate(tc.in))
 		})
 	}
 }
 
-func TestParseConditions(t *testing.T) {
+func TestPath(t *testing.T) {
 	tests := []struct {
 		name string
-		tag  string
-		cond []Condition
-		err  error
+		path Path
+		str  string
+	}{
+		{name: "empty", path: Path{}, str: ""},
+		{name: "field", path: Path{{Name: "Phones"}}, str: "Phones"},
+		{name: "element", path: Path{{Name: "Phones"}, {Index: 2}}, str: "Phones[2]"},
+		{name: "nested elements", path: Path{{Name: "Matrix"}, {Index: 0}, {Index: 10}}, str: "Matrix[0][10]"},
+		{name: "map key", path: Path{{Name: "Quotas"}, {Key: "cpu"}}, str: "Quotas[cpu]"},
+		{name: "map values", path: Path{{Name: "Limits"}, {Key: 8}, {Name: "Max"}}, str: "Limits[8].Max"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.str, tc.path.String())
+		})
+	}
+
+	err := Validate(User{
+		ID:     "7f0e3265-ca96-4b33-8858-fef9696cc71b",
+		Age:    18,
+		Email:  "somemail@gmail.com",
+		Role:   "admin",
+		Phones: []string{"12345678901", "12345678901", "123"},
+	})
+	require.EqualError(t, err, "Phones[2]: "+ErrValidationStrLen.Error())
+}
+
+func TestValidateField(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		tag   string
+		value interface{}
+		err   error
 	}{
 		{
-			name: "length tag",
-			tag:  "len:5",
-			cond: []Condition{{"len", "5"}},
-			err:  nil,
-		},
-		{
-			name: "regexp tag",
-			tag:  "regexp:regarg",
-			cond: []Condition{{"regexp", "regarg"}},
-			err:  nil,
-		},
-		{
-			name: "in string tag",
-			tag:  "in:first,second",
-			cond: []Condition{{"in", "first,second"}},
-			err:  nil,
-		},
-		{
-			name: "min tag",
-			tag:  "min:10",
-			cond: []Condition{{"min", "10"}},
-			err:  nil,
+			name:  "string",
+			field: "StrTag",
+			tag:   "in:foo|regexp:foo|len:3",
+			value: "foo",
+			err:   nil,
 		},
 		{
-			name: "max tag",
-			tag:  "max:20",
-			cond: []Condition{{"max", "20"}},
-			err:  nil,
+			name:  "string slice",
+			field: "StrTag",
+			tag:   "in:foo|regexp:foo|len:3",
+			value: []string{"foo", "bar"},
+			err: ValidationErrors{
+				fieldError(ErrValidationStrIn, "StrTag", 1),
+				fieldError(ErrValidationStrRegexp, "StrTag", 1),
+			},
 		},
 		{
-			name: "in int tag",
-			tag:  "in:15,16",
-			cond: []Condition{{"in", "15,16"}},
-			err:  nil,
+			name:  "string, validation error",
+			field: "StrTag",
+			tag:   "in:foo|regexp:foo|len:6",
+			value: "bar",
+			err: ValidationErrors{
+				fieldError(ErrValidationStrIn, "StrTag"),
+				fieldError(ErrValidationStrRegexp, "StrTag"),
+				fieldError(ErrValidationStrLen, "StrTag"),
+			},
 		},
 		{
-			name: "multi string tag",
-			tag:  "len:5|regexp:regarg|in:first",
-			cond: []Condition{{"len", "5"}, {"regexp", "regarg"}, {"in", "first"}},
-			err:  nil,
+			name:  "int",
+			field: "IntTag",
+			tag:   "in:20,25,30|max:30|min:20",
+			value: 25,
+			err:   nil,
 		},
 		{
-			name: "multi int tag",
-			tag:  "min:5|max:20|in:15",
-			cond: []Condition{{"min", "5"}, {"max", "20"}, {"in", "15"}},
-			err:  nil,
+			name:  "int slice",
+			field: "IntTag",
+			tag:   "in:5,15|max:16|min:4",
+			value: []int{10, 20},
+			err: ValidationErrors{
+				fieldError(ErrValidationIntIn, "IntTag", 0),
+				fieldError(ErrValidationIntIn, "IntTag", 1),
+				fieldError(ErrValidationIntMax, "IntTag", 1),
+			},
 		},
 		{
-			name: "without operand",
-			tag:  "nested",
-			cond: []Condition{{"nested", ""}},
-			err:  nil,
+			name:  "int, validation error",
+			field: "IntTag",
+			tag:   "in:20,30|max:30|min:60",
+			value: 50,
+			err: ValidationErrors{
+				fieldError(ErrValidationIntIn, "IntTag"),
+				fieldError(ErrValidationIntMax, "IntTag"),
+				fieldError(ErrValidationIntMin, "IntTag"),
+			},
 		},
 		{
-			name: "validation format error",
-			tag:  "validation:format:error",
-			cond: nil,
-			err:  ErrValidationFormat,
+			name:  "unsupported type",
+			field: "ComplexTag",
+			tag:   "validate:tag",
+			value: complex(1, 2),
+			err:   ErrUnsupType,
 		},
 	}
 
+	var verr ValidationErrors
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			c, err := parseConditions(tc.tag)
-			require.Equal(t, c, tc.cond)
-			require.Equal(t, err, tc.err)
+			err := validateTagged(tc.field, tc.tag, tc.value)
+			if errors.As(err, &verr) {
+				require.Equal(t, tc.err, verr)
+				return
+			}
+			require.Equal(t, tc.err, err)
 		})
 	}
 }
+
+// validateTagged validates value as a struct field with the given name and tag.
+func validateTagged(name, tag string, value interface{}) error {
+	f, err := defaultValidator.newFieldPlan(nil, name, reflect.TypeOf(value), tag)
+	if err != nil {
+		return err
+	}
+	w := walker{v: defaultValidator, ctx: context.Background()}
+	if err := w.validateValue(reflect.ValueOf(value), f.valuePlan, Path{{Name: name}}, reflect.Value{}); err != nil {
+		return err
+	}
+	if len(w.ve) != 0 {
+		return w.ve
+	}
+	return nil
+}
+
+// checkRule compiles the single condition of tag against the type of value
+// and checks value with it.
+func checkRule(tag string, value interface{}) error {
+	cond, err := parseConditions(tag, defaultSyntax)
+	if err != nil {
+		return err
+	}
+	r, err := defaultValidator.compileRule(cond[0], nil, reflect.TypeOf(value))
+	if err != nil {
+		return err
+	}
+	return r.check(FieldLevel{Context: context.Background(), Value: reflect.ValueOf(value), Operand: r.operand})
+}
+
+// cond is the Condition parsed from operator and operand with the default syntax.
+func cond(operator, operand string) Condition {
+	return Condition{operator: operator, operand: operand, params: strings.Split(operand, inSplitSymbol)}
+}
+
+// fieldError is the ValidationError reported for err at the path made of
+// elems: strings are field names, ints are slice indexes and PathElems are
+// taken as is, e.g. for map keys.
+func fieldError(err error, elems ...interface{}) ValidationError {
+	path := Path{}
+	for _, e := range elems {
+		switch e := e.(type) {
+		case string:
+			path = append(path, PathElem{Name: e})
+		case int:
+			path = append(path, PathElem{Index: e})
+		case PathElem:
+			path = append(path, e)
+		}
+	}
+	return newValidationError(path, err)
+}
@@ -0,0 +1,217 @@
+package struct_validator
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrValidationTimeBefore  = errors.New("validation error, time isn't before the expected")
+	ErrValidationTimeAfter   = errors.New("validation error, time isn't after the expected")
+	ErrValidationTimePast    = errors.New("validation error, time isn't in the past")
+	ErrValidationTimeFuture  = errors.New("validation error, time isn't in the future")
+	ErrValidationTimeWithin  = errors.New("validation error, time isn't within the expected duration from now")
+	ErrValidationTimeWeekday = errors.New("validation error, time isn't on an expected weekday")
+	ErrValidationTimeHour    = errors.New("validation error, time isn't within the expected hours")
+
+	ErrValidationDurationMin = errors.New("validation error, duration less than expected")
+	ErrValidationDurationMax = errors.New("validation error, duration greater than expected")
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// timeRule compiles c for a time.Time, now is the clock past, future and
+// within are checked against. Weekdays and hours are taken in the location of
+// the value:
+//
+//	before:2006-01-02 / after:'2006-01-02T15:04:05Z07:00'
+//	past / future
+//	within:24h            - at most the duration away from now, either way
+//	weekday:mon,tuesday   - on one of the weekdays
+//	hour:9-17             - from 9:00 to 16:59, a window like 22-6 wraps midnight
+func timeRule(c Condition, now func() time.Time) (rule, error) {
+	switch c.operator {
+	case "before", "after":
+		at, err := parseTime(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		if c.operator == "before" {
+			return rule{c, func(fl FieldLevel) error { return validateTimeBefore(timeOf(fl), at) }}, nil
+		}
+		return rule{c, func(fl FieldLevel) error { return validateTimeAfter(timeOf(fl), at) }}, nil
+	case "past", "future":
+		if c.operand != "" {
+			return rule{}, ErrInvalidOperand
+		}
+		if c.operator == "past" {
+			return rule{c, func(fl FieldLevel) error { return validateTimePast(timeOf(fl), now()) }}, nil
+		}
+		return rule{c, func(fl FieldLevel) error { return validateTimeFuture(timeOf(fl), now()) }}, nil
+	case "within":
+		d, err := time.ParseDuration(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		if d < 0 {
+			return rule{}, ErrInvalidOperand
+		}
+		return rule{c, func(fl FieldLevel) error { return validateTimeWithin(timeOf(fl), now(), d) }}, nil
+	case "weekday":
+		days, err := parseWeekdays(c.params)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateTimeWeekday(timeOf(fl), days) }}, nil
+	case "hour":
+		from, to, err := parseHours(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateTimeHour(timeOf(fl), from, to) }}, nil
+	default:
+		return rule{}, ErrUnsupCondition
+	}
+}
+
+// durationRule compiles c for a time.Duration, its operands are parsed by
+// time.ParseDuration, e.g. "1h30m".
+func durationRule(c Condition) (rule, error) {
+	switch c.operator {
+	case "min":
+		m, err := time.ParseDuration(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateDurationMin(time.Duration(fl.Value.Int()), m) }}, nil
+	case "max":
+		m, err := time.ParseDuration(c.operand)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{c, func(fl FieldLevel) error { return validateDurationMax(time.Duration(fl.Value.Int()), m) }}, nil
+	default:
+		return rule{}, ErrUnsupCondition
+	}
+}
+
+func timeOf(fl FieldLevel) time.Time {
+	return fl.Value.Interface().(time.Time)
+}
+
+// parseTime parses an RFC 3339 time or a date, taken at midnight UTC.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+func parseWeekdays(names []string) ([]time.Weekday, error) {
+	days := []time.Weekday{}
+	for _, name := range names {
+		day, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return nil, ErrInvalidOperand
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseHours parses a "from-to" window of hours of a day.
+func parseHours(s string) (from, to int, err error) {
+	f, t, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, ErrInvalidOperand
+	}
+	if from, err = strconv.Atoi(f); err != nil {
+		return 0, 0, err
+	}
+	if to, err = strconv.Atoi(t); err != nil {
+		return 0, 0, err
+	}
+	if from < 0 || from > 23 || to < 0 || to > 24 || from == to {
+		return 0, 0, ErrInvalidOperand
+	}
+	return from, to, nil
+}
+
+func validateTimeBefore(t, at time.Time) error {
+	if !t.Before(at) {
+		return ErrValidationTimeBefore
+	}
+	return nil
+}
+
+func validateTimeAfter(t, at time.Time) error {
+	if !t.After(at) {
+		return ErrValidationTimeAfter
+	}
+	return nil
+}
+
+func validateTimePast(t, now time.Time) error {
+	if !t.Before(now) {
+		return ErrValidationTimePast
+	}
+	return nil
+}
+
+func validateTimeFuture(t, now time.Time) error {
+	if !t.After(now) {
+		return ErrValidationTimeFuture
+	}
+	return nil
+}
+
+func validateTimeWithin(t, now time.Time, d time.Duration) error {
+	if t.Before(now.Add(-d)) || t.After(now.Add(d)) {
+		return ErrValidationTimeWithin
+	}
+	return nil
+}
+
+func validateTimeWeekday(t time.Time, days []time.Weekday) error {
+	for _, day := range days {
+		if t.Weekday() == day {
+			return nil
+		}
+	}
+	return ErrValidationTimeWeekday
+}
+
+func validateTimeHour(t time.Time, from, to int) error {
+	h := t.Hour()
+	if from < to && (h < from || h >= to) || from > to && h < from && h >= to {
+		return ErrValidationTimeHour
+	}
+	return nil
+}
+
+func validateDurationMin(d, min time.Duration) error {
+	if d < min {
+		return ErrValidationDurationMin
+	}
+	return nil
+}
+
+func validateDurationMax(d, max time.Duration) error {
+	if d > max {
+		return ErrValidationDurationMax
+	}
+	return nil
+}
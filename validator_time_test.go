@@ -0,0 +1,155 @@
+package struct_validator
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTime(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	v := New(WithClock(func() time.Time { return now }))
+
+	tests := []struct {
+		tag   string
+		value time.Time
+		err   error
+	}{
+		{tag: "before:2024-06-01", value: now, err: nil},
+		{tag: "before:2024-05-15", value: now, err: ErrValidationTimeBefore},
+		{tag: "before:'2024-05-15T12:00:00Z'", value: now, err: ErrValidationTimeBefore},
+		{tag: "after:'2024-05-15T13:00:00+02:00'", value: now, err: nil},
+		{tag: "after:2024-06-01", value: now, err: ErrValidationTimeAfter},
+		{tag: "past", value: now.Add(-time.Second), err: nil},
+		{tag: "past", value: now, err: ErrValidationTimePast},
+		{tag: "future", value: now.Add(time.Second), err: nil},
+		{tag: "future", value: now.Add(-time.Hour), err: ErrValidationTimeFuture},
+		{tag: "within:24h", value: now.Add(-23 * time.Hour), err: nil},
+		{tag: "within:24h", value: now.Add(24 * time.Hour), err: nil},
+		{tag: "within:24h", value: now.Add(25 * time.Hour), err: ErrValidationTimeWithin},
+		{tag: "within:1h30m", value: now.Add(-2 * time.Hour), err: ErrValidationTimeWithin},
+		{tag: "weekday:mon,Wednesday", value: now, err: nil},
+		{tag: "weekday:sat,sun", value: now, err: ErrValidationTimeWeekday},
+		{tag: "hour:9-17", value: now, err: nil},
+		{tag: "hour:9-12", value: now, err: ErrValidationTimeHour},
+		{tag: "hour:22-6", value: now.Add(-9 * time.Hour), err: nil},
+		{tag: "hour:22-6", value: now.Add(11 * time.Hour), err: nil},
+		{tag: "hour:22-6", value: now, err: ErrValidationTimeHour},
+		{tag: "hour:0-24", value: now, err: nil},
+		{tag: "future|weekday:mon,tue,wed,thu,fri", value: now.Add(72 * time.Hour), err: ErrValidationTimeWeekday},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag+" "+tc.value.String(), func(t *testing.T) {
+			f, err := v.newFieldPlan(nil, "At", timeType, tc.tag)
+			require.NoError(t, err)
+			w := walker{v: v, ctx: context.Background()}
+			require.NoError(t, w.validateValue(reflect.ValueOf(tc.value), f.valuePlan, Path{{Name: "At"}}, reflect.Value{}))
+			if tc.err == nil {
+				require.Empty(t, w.ve)
+				return
+			}
+			require.Equal(t, ValidationErrors{fieldError(tc.err, "At")}, w.ve)
+		})
+	}
+}
+
+func TestValidateTimeLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	at := time.Date(2024, 5, 15, 23, 0, 0, 0, time.UTC).In(loc) // Thursday 9:00
+
+	require.NoError(t, checkRule("weekday:thu", at))
+	require.NoError(t, checkRule("hour:9-10", at))
+}
+
+func TestValidateDuration(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value time.Duration
+		err   error
+	}{
+		{tag: "min:1s", value: time.Second, err: nil},
+		{tag: "min:1m30s", value: time.Minute, err: ErrValidationDurationMin},
+		{tag: "max:24h", value: 24 * time.Hour, err: nil},
+		{tag: "max:500ms", value: time.Second, err: ErrValidationDurationMax},
+		{tag: "max:-1s", value: 0, err: ErrValidationDurationMax},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag, func(t *testing.T) {
+			require.Equal(t, tc.err, checkRule(tc.tag, tc.value))
+		})
+	}
+}
+
+func TestValidateTimeStruct(t *testing.T) {
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	v := New(WithClock(func() time.Time { return now }))
+
+	type Booking struct {
+		CreatedAt time.Time       `validate:"past"`
+		StartsAt  *time.Time      `validate:"required|future|within:720h"`
+		EndsAt    time.Time       `validate:"omitempty|gtfield:StartsAt"`
+		Timeout   time.Duration   `validate:"min:1s|max:1m"`
+		Retries   []time.Duration `validate:"max:10s"`
+	}
+
+	startsAt := now.Add(48 * time.Hour)
+	require.NoError(t, v.Validate(Booking{
+		CreatedAt: now.Add(-time.Minute),
+		StartsAt:  &startsAt,
+		Timeout:   30 * time.Second,
+		Retries:   []time.Duration{time.Second},
+	}))
+
+	startsAt = now.Add(-time.Hour)
+	require.Equal(t, ValidationErrors{
+		fieldError(ErrValidationTimePast, "CreatedAt"),
+		fieldError(ErrValidationTimeFuture, "StartsAt"),
+		fieldError(&CrossFieldError{Field: "StartsAt", Err: ErrValidationGtField}, "EndsAt"),
+		fieldError(ErrValidationDurationMin, "Timeout"),
+		fieldError(ErrValidationDurationMax, "Retries", 1),
+	}, v.Validate(Booking{
+		CreatedAt: now,
+		StartsAt:  &startsAt,
+		EndsAt:    now.Add(-2 * time.Hour),
+		Timeout:   time.Millisecond,
+		Retries:   []time.Duration{time.Second, time.Minute},
+	}))
+}
+
+func TestTimeRuleErrors(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   error
+	}{
+		{tag: "before:tomorrow", value: time.Time{}, err: nil},
+		{tag: "past:1h", value: time.Time{}, err: ErrInvalidOperand},
+		{tag: "within:-1h", value: time.Time{}, err: ErrInvalidOperand},
+		{tag: "within:1d", value: time.Time{}, err: nil},
+		{tag: "weekday:funday", value: time.Time{}, err: ErrInvalidOperand},
+		{tag: "hour:9", value: time.Time{}, err: ErrInvalidOperand},
+		{tag: "hour:9-25", value: time.Time{}, err: ErrInvalidOperand},
+		{tag: "hour:9-9", value: time.Time{}, err: ErrInvalidOperand},
+		{tag: "hour:a-9", value: time.Time{}, err: strconv.ErrSyntax},
+		{tag: "len:5", value: time.Time{}, err: ErrUnsupCondition},
+		{tag: "min:5", value: time.Duration(0), err: nil},
+		{tag: "in:1s", value: time.Duration(0), err: ErrUnsupCondition},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tag, func(t *testing.T) {
+			err := checkRule(tc.tag, tc.value)
+			require.Error(t, err)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}